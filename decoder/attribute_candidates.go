@@ -15,17 +15,26 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
-func attributeSchemaToCandidate(ctx context.Context, name string, attr *schema.AttributeSchema, rng hcl.Range) lang.Candidate {
+func attributeSchemaToCandidate(ctx context.Context, name string, attr *schema.AttributeSchema, rng hcl.Range, nestingLevel int) lang.Candidate {
 	var snippet string
 	var triggerSuggest bool
-	cData := attr.Constraint.EmptyCompletionData(ctx, 1, 0)
+	cData := attr.Constraint.EmptyCompletionData(ctx, 1, nestingLevel)
 	snippet = fmt.Sprintf("%s = %s", name, cData.Snippet)
 	triggerSuggest = cData.TriggerSuggest
+	if attr.DefaultSnippet != "" {
+		snippet = fmt.Sprintf("%s = %s", name, attr.DefaultSnippet)
+		triggerSuggest = false
+	} else if defaultSnippet, ok := defaultValueSnippet(attr); ok {
+		snippet = fmt.Sprintf("%s = %s", name, defaultSnippet)
+		triggerSuggest = false
+	}
+
+	description, resolveHook := candidateDescription(ctx, name, attr.Description, attr.DescriptionFunc)
 
 	return lang.Candidate{
 		Label:        name,
 		Detail:       detailForAttribute(attr),
-		Description:  attr.Description,
+		Description:  description,
 		IsDeprecated: attr.IsDeprecated,
 		Kind:         lang.AttributeCandidateKind,
 		TextEdit: lang.TextEdit{
@@ -34,9 +43,30 @@ func attributeSchemaToCandidate(ctx context.Context, name string, attr *schema.A
 			Range:   rng,
 		},
 		TriggerSuggest: triggerSuggest,
+		ResolveHook:    resolveHook,
+		SortText:       sortTextForAttribute(name, attr),
 	}
 }
 
+// sortTextForAttribute returns sort text placing prioritized
+// attributes ahead of the remaining ones which are sorted by label.
+// Priority is zero-padded, so that it sorts numerically.
+func sortTextForAttribute(name string, attr *schema.AttributeSchema) string {
+	if attr.Priority == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%020d %s", attr.Priority, name)
+}
+
+// descriptionForAttribute returns the attribute description,
+// calling DescriptionFunc if one is provided
+func descriptionForAttribute(attr *schema.AttributeSchema) lang.MarkupContent {
+	if attr.DescriptionFunc != nil {
+		return attr.DescriptionFunc()
+	}
+	return attr.Description
+}
+
 func detailForAttribute(attr *schema.AttributeSchema) string {
 	details := []string{}
 
@@ -60,9 +90,56 @@ func detailForAttribute(attr *schema.AttributeSchema) string {
 		details = append(details, friendlyName)
 	}
 
+	if attr.NumberRange != nil {
+		details = append(details, attr.NumberRange.String())
+	}
+
+	if attr.MinLength != 0 {
+		details = append(details, fmt.Sprintf("min length %d", attr.MinLength))
+	}
+	if attr.MaxLength != 0 {
+		details = append(details, fmt.Sprintf("max length %d", attr.MaxLength))
+	}
+
+	if val, ok := primitiveDefaultValue(attr); ok {
+		details = append(details, fmt.Sprintf("default: %s", literalValueText(val)))
+	}
+
 	return strings.Join(details[:], ", ")
 }
 
+// primitiveDefaultValue returns the known default value
+// of the attribute, if it is of a primitive type
+func primitiveDefaultValue(attr *schema.AttributeSchema) (cty.Value, bool) {
+	dv, ok := attr.DefaultValue.(schema.DefaultValue)
+	if !ok {
+		return cty.NilVal, false
+	}
+	if dv.Value == cty.NilVal || !dv.Value.IsKnown() || dv.Value.IsNull() || !dv.Value.Type().IsPrimitiveType() {
+		return cty.NilVal, false
+	}
+	return dv.Value, true
+}
+
+// defaultValueSnippet returns the attribute value snippet
+// with the default value prefilled as the placeholder,
+// e.g. ${1:1} or "${1:value}"
+func defaultValueSnippet(attr *schema.AttributeSchema) (string, bool) {
+	val, ok := primitiveDefaultValue(attr)
+	if !ok {
+		return "", false
+	}
+	if val.Type() == cty.Number && attr.NumberRange != nil && !attr.NumberRange.Contains(val) {
+		return "", false
+	}
+
+	text := literalValueText(val)
+	if val.Type() == cty.String {
+		return fmt.Sprintf(`"${1:%s}"`, escapeSnippet(text[1:len(text)-1])), true
+	}
+	return fmt.Sprintf("${1:%s}", escapeSnippet(text)), true
+}
+
 func sortedObjectAttrNames(obj cty.Type) []string {
 	if !obj.IsObjectType() {
 		return []string{}
@@ -4,6 +4,7 @@
 package decoder
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -13,10 +14,10 @@ import (
 )
 
 // blockSchemaToCandidate generates a lang.Candidate used for auto-complete inside an editor from a BlockSchema.
-// If `prefillRequiredFields` is `false`, it returns a snippet that does not expect any prefilled fields.
-// If `prefillRequiredFields` is `true`, it returns a snippet that is compatiable with a list of prefilled fields from `generateRequiredFieldsSnippet`
-func (d *PathDecoder) blockSchemaToCandidate(blockType string, block *schema.BlockSchema, rng hcl.Range) lang.Candidate {
+// If `PrefillRequiredFields` is `true`, the snippet is compatible with a list of prefilled fields from `generateRequiredFieldsSnippet`
+func (d *PathDecoder) blockSchemaToCandidate(ctx context.Context, blockType string, block *schema.BlockSchema, rng hcl.Range) lang.Candidate {
 	triggerSuggest := false
+	var triggerSuggestChars []string
 	if len(block.Labels) > 0 {
 		// We make some naive assumptions here for simplicity
 		// and because this works just well enough for Terraform.
@@ -27,85 +28,170 @@ func (d *PathDecoder) blockSchemaToCandidate(blockType string, block *schema.Blo
 		// but it would likely involve changes in snippet placeholder
 		// numbering and full understanding of UX implications.
 		triggerSuggest = block.Labels[0].IsDepKey
+		if triggerSuggest {
+			// label candidates are available inside the quotes
+			triggerSuggestChars = []string{`"`}
+		}
 	}
 
+	description, resolveHook := candidateDescription(ctx, blockType, block.Description, block.DescriptionFunc)
+
 	return lang.Candidate{
 		Label:        blockType,
 		Detail:       detailForBlock(block),
-		Description:  block.Description,
+		Description:  description,
 		IsDeprecated: block.IsDeprecated,
 		Kind:         lang.BlockCandidateKind,
 		TextEdit: lang.TextEdit{
 			NewText: blockType,
-			Snippet: snippetForBlock(blockType, block, d.PrefillRequiredFields),
-			Range:   rng,
+			Snippet: block.Snippet(blockType, schema.SnippetStyle{
+				PrefillRequiredFields: d.PrefillRequiredFields,
+			}),
+			Range: rng,
 		},
-		TriggerSuggest: triggerSuggest,
+		TriggerSuggest:      triggerSuggest,
+		TriggerSuggestChars: triggerSuggestChars,
+		ResolveHook:         resolveHook,
 	}
 }
 
-// detailForBlock returns a `Detail` info string to display in an editor in a hover event
-func detailForBlock(block *schema.BlockSchema) string {
-	detail := "Block"
-	if block.Type != schema.BlockTypeNil {
-		detail += fmt.Sprintf(", %s", block.Type)
-	}
+// labelPrefilledBlockCandidates generates a candidate per each combination
+// of dependency-key label values declared in the block's dependent body
+// schemas, with these labels prefilled. Keys depending on attributes
+// are ignored, as are keys not covering all dependency-key labels.
+func labelPrefilledBlockCandidates(ctx context.Context, blockType string, block *schema.BlockSchema, rng hcl.Range) []lang.Candidate {
+	candidates := make([]lang.Candidate, 0)
 
-	if block.MinItems > 0 {
-		detail += fmt.Sprintf(", min: %d", block.MinItems)
-	}
-	if block.MaxItems > 0 {
-		detail += fmt.Sprintf(", max: %d", block.MaxItems)
-	}
+	for _, schemaKey := range sortedSchemaKeys(block.DependentBody) {
+		if ctx.Err() != nil {
+			return candidates
+		}
+		depKeys, err := decodeSchemaKey(schemaKey)
+		if err != nil || len(depKeys.Labels) == 0 || len(depKeys.Attributes) > 0 {
+			continue
+		}
 
-	return strings.TrimSpace(detail)
-}
+		values := make(map[int]string, len(depKeys.Labels))
+		for _, label := range depKeys.Labels {
+			values[label.Index] = label.Value
+		}
 
-// snippetForBlock takes a block and returns a formatted snippet for a user to complete inside an editor.
-// If `prefillRequiredFields` is `false`, it returns a snippet that does not expect any prefilled fields.
-// If `prefillRequiredFields` is `true`, it returns a snippet that is compatiable with a list of prefilled fields from `generateRequiredFieldsSnippet`
-func snippetForBlock(blockType string, block *schema.BlockSchema, prefillRequiredFields bool) string {
-	if prefillRequiredFields {
-		labels := ""
-
-		depKey := false
-		for _, l := range block.Labels {
-			if l.IsDepKey {
-				depKey = true
+		label := blockType
+		snippet := blockType
+		placeholder := 1
+		complete := true
+		for i, labelSchema := range block.Labels {
+			if !labelSchema.IsDepKey {
+				snippet += fmt.Sprintf(` "${%d:%s}"`, placeholder, labelSchema.Name)
+				placeholder++
+				continue
 			}
-		}
 
-		if depKey {
-			for _, l := range block.Labels {
-				if l.IsDepKey {
-					labels += ` "${0}"`
-				} else {
-					labels += fmt.Sprintf(` "%s"`, l.Name)
-				}
+			value, ok := values[i]
+			if !ok {
+				complete = false
+				break
 			}
-			return fmt.Sprintf("%s%s {\n}", blockType, labels)
+			delete(values, i)
+
+			label += fmt.Sprintf(` "%s"`, escapeQuotedLabel(value))
+			snippet += fmt.Sprintf(` "%s"`, escapeSnippet(escapeQuotedLabel(value)))
+		}
+		if !complete || len(values) > 0 {
+			continue
 		}
 
-		placeholder := 1
-		for _, l := range block.Labels {
-			labels += fmt.Sprintf(` "${%d:%s}"`, placeholder, l.Name)
-			placeholder++
+		bodySchema := block.DependentBody[schemaKey]
+		detail := detailForBlock(block)
+		if bodySchema != nil && bodySchema.Detail != "" {
+			detail = bodySchema.Detail
+		}
+		var description lang.MarkupContent
+		var resolveHook *lang.ResolveHook
+		if bodySchema != nil && bodySchema.Description.Value != "" {
+			description = bodySchema.Description
+		} else {
+			description, resolveHook = candidateDescription(ctx, blockType, block.Description, block.DescriptionFunc)
 		}
 
-		return fmt.Sprintf("%s%s {\n  ${%d}\n}", blockType, labels, placeholder)
+		candidates = append(candidates, lang.Candidate{
+			Label:        label,
+			Detail:       detail,
+			Description:  description,
+			IsDeprecated: block.IsDeprecated,
+			Kind:         lang.BlockCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: label,
+				Snippet: fmt.Sprintf("%s {\n  ${%d}\n}", snippet, placeholder),
+				Range:   rng,
+			},
+			ResolveHook: resolveHook,
+		})
 	}
 
-	labels := ""
-	placeholder := 1
+	return candidates
+}
 
-	for _, l := range block.Labels {
-		if l.IsDepKey {
-			labels += fmt.Sprintf(` "${%d}"`, placeholder)
-		} else {
-			labels += fmt.Sprintf(` "${%d:%s}"`, placeholder, l.Name)
+// dynamicBlockCandidates generates a "dynamic" block candidate per each
+// repeatable block type which can be generated dynamically in the body,
+// i.e. per each label value of the dynamic block's dependent body.
+// The snippet contains the for_each attribute and the content block.
+func dynamicBlockCandidates(bodySchema *schema.BodySchema, dynamicBlock *schema.BlockSchema, rng hcl.Range) []lang.Candidate {
+	candidates := make([]lang.Candidate, 0)
+
+	for _, schemaKey := range sortedSchemaKeys(dynamicBlock.DependentBody) {
+		depKeys, err := decodeSchemaKey(schemaKey)
+		if err != nil || len(depKeys.Labels) != 1 || depKeys.Labels[0].Index != 0 {
+			continue
+		}
+
+		blockType := depKeys.Labels[0].Value
+		targetBlock, ok := bodySchema.Blocks[blockType]
+		if !ok || targetBlock.MaxItems == 1 {
+			continue
 		}
-		placeholder++
+
+		label := fmt.Sprintf(`dynamic "%s"`, escapeQuotedLabel(blockType))
+		snippet := fmt.Sprintf(`dynamic "%s"`, escapeSnippet(escapeQuotedLabel(blockType)))
+
+		candidates = append(candidates, lang.Candidate{
+			Label:       label,
+			Detail:      detailForBlock(dynamicBlock),
+			Description: lang.Markdown(fmt.Sprintf("Produces `%s` blocks dynamically by iterating over a given complex value", blockType)),
+			Kind:        lang.BlockCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: label,
+				Snippet: fmt.Sprintf("%s {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}", snippet),
+				Range:   rng,
+			},
+		})
 	}
 
-	return fmt.Sprintf("%s%s {\n  ${%d}\n}", blockType, labels, placeholder)
+	return candidates
+}
+
+// descriptionForBlock returns the block description,
+// calling DescriptionFunc if one is provided
+func descriptionForBlock(block *schema.BlockSchema) lang.MarkupContent {
+	if block.DescriptionFunc != nil {
+		return block.DescriptionFunc()
+	}
+	return block.Description
+}
+
+// detailForBlock returns a `Detail` info string to display in an editor in a hover event
+func detailForBlock(block *schema.BlockSchema) string {
+	detail := "Block"
+	if block.Type != schema.BlockTypeNil {
+		detail += fmt.Sprintf(", %s", block.Type)
+	}
+
+	if block.MinItems > 0 {
+		detail += fmt.Sprintf(", min: %d", block.MinItems)
+	}
+	if block.MaxItems > 0 {
+		detail += fmt.Sprintf(", max: %d", block.MaxItems)
+	}
+
+	return strings.TrimSpace(detail)
 }
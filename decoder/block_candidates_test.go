@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestBlockSchema_Snippet_matchesCompletion(t *testing.T) {
+	blockSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "type", IsDepKey: true},
+			{Name: "name"},
+		},
+		Body: &schema.BodySchema{},
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": blockSchema,
+		},
+	}
+
+	testCases := []struct {
+		testName              string
+		prefillRequiredFields bool
+		expectedSnippet       string
+	}{
+		{
+			"default",
+			false,
+			"resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
+		},
+		{
+			"prefill required fields",
+			true,
+			"resource \"${0}\" \"name\" {\n}",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			snippet := blockSchema.Snippet("resource", schema.SnippetStyle{
+				PrefillRequiredFields: tc.prefillRequiredFields,
+			})
+			if diff := cmp.Diff(tc.expectedSnippet, snippet); diff != "" {
+				t.Fatalf("unexpected snippet: %s", diff)
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.PrefillRequiredFields = tc.prefillRequiredFields
+
+			candidates, err := d.CompletionAtPos(context.Background(), "test.tf", hcl.InitialPos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(candidates.List) != 1 {
+				t.Fatalf("expected 1 candidate, got %d", len(candidates.List))
+			}
+			if diff := cmp.Diff(snippet, candidates.List[0].TextEdit.Snippet); diff != "" {
+				t.Fatalf("snippet does not match completion: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_expandNestedBlockLabels(t *testing.T) {
+	ingressSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "protocol", IsDepKey: true},
+			{Name: "name"},
+		},
+		Body: &schema.BodySchema{},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			schema.NewSchemaKey(schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "tcp"},
+				},
+			}): {},
+			schema.NewSchemaKey(schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "udp"},
+				},
+			}): {
+				Detail: "UDP rule",
+			},
+		},
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"ingress": ingressSchema,
+			"firewall": {
+				Body: &schema.BodySchema{
+					Blocks: map[string]*schema.BlockSchema{
+						"ingress": ingressSchema,
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte("firewall {\n  in\n}\nin\n")
+	f, _ := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.decoderCtx.Completion.ExpandNestedBlockLabels = true
+
+	nestedRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 2, Column: 3, Byte: 13},
+		End:      hcl.Pos{Line: 2, Column: 5, Byte: 15},
+	}
+	rootRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 4, Column: 1, Byte: 18},
+		End:      hcl.Pos{Line: 4, Column: 3, Byte: 20},
+	}
+
+	testCases := []struct {
+		testName           string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"nested block",
+			nestedRng.End,
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "ingress",
+					Detail: "Block",
+					Kind:   lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "ingress",
+						Snippet: "ingress \"${1}\" \"${2:name}\" {\n  ${3}\n}",
+						Range:   nestedRng,
+					},
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
+				},
+				{
+					Label:  `ingress "tcp"`,
+					Detail: "Block",
+					Kind:   lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `ingress "tcp"`,
+						Snippet: "ingress \"tcp\" \"${1:name}\" {\n  ${2}\n}",
+						Range:   nestedRng,
+					},
+				},
+				{
+					Label:  `ingress "udp"`,
+					Detail: "UDP rule",
+					Kind:   lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `ingress "udp"`,
+						Snippet: "ingress \"udp\" \"${1:name}\" {\n  ${2}\n}",
+						Range:   nestedRng,
+					},
+				},
+			}),
+		},
+		{
+			"root block",
+			rootRng.End,
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "ingress",
+					Detail: "Block",
+					Kind:   lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "ingress",
+						Snippet: "ingress \"${1}\" \"${2:name}\" {\n  ${3}\n}",
+						Range:   rootRng,
+					},
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			candidates, err := d.CompletionAtPos(context.Background(), "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
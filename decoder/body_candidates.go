@@ -4,7 +4,9 @@
 package decoder
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -16,61 +18,102 @@ import (
 )
 
 // bodySchemaCandidates returns candidates for completion of fields inside a body or block.
-func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.Body, schema *schema.BodySchema, prefixRng, editRng hcl.Range) lang.Candidates {
+func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.Body, bodySchema *schema.BodySchema, prefixRng, editRng hcl.Range) lang.Candidates {
 	prefix, _ := d.bytesFromRange(prefixRng)
 
 	candidates := lang.NewCandidates()
-	count := 0
+	if d.isPrefixTooShort(ctx, string(prefix)) {
+		return candidates
+	}
+
+	nestingLevel := d.nestingLevelAtPos(editRng.Filename, editRng.Start)
+
+	if ext := schema.BodyExtensionsFromContext(ctx); ext != nil {
+		// check if count or for_each attribute is already declared,
+		// so we don't suggest a duplicate or a conflicting one
+		// as a block cannot use both count and for_each
+		_, countDeclared := body.Attributes["count"]
+		_, forEachDeclared := body.Attributes["for_each"]
 
-	if schema.Extensions != nil {
 		// check if count attribute "extension" is enabled here
-		if schema.Extensions.Count {
-			// check if count attribute is already declared, so we don't
-			// suggest a duplicate
-			if _, ok := body.Attributes["count"]; !ok {
-				candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "count", schemahelper.CountAttributeSchema(), editRng))
-			}
+		if ext.Count && !countDeclared && !forEachDeclared {
+			candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "count", schemahelper.CountAttributeSchema(), editRng, nestingLevel))
 		}
 
-		if schema.Extensions.ForEach {
-			// check if for_each attribute is already declared, so we don't
-			// suggest a duplicate
-			if _, present := body.Attributes["for_each"]; !present {
-				candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "for_each", schemahelper.ForEachAttributeSchema(), editRng))
-			}
+		if ext.ForEach && !forEachDeclared && !countDeclared {
+			candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "for_each", schemahelper.ForEachAttributeSchema(), editRng, nestingLevel))
+		}
+
+		if _, declared := body.Attributes["depends_on"]; ext.DependsOn && !declared {
+			candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "depends_on", schemahelper.DependsOnAttributeSchema(ext.DependsOnScopeIds), editRng, nestingLevel))
 		}
 	}
 
-	if len(schema.Attributes) > 0 {
-		attrNames := sortedAttributeNames(schema.Attributes)
+	if len(bodySchema.Attributes) > 0 {
+		attrNames := sortedAttributeNames(bodySchema.Attributes)
 		for _, name := range attrNames {
-			attr := schema.Attributes[name]
+			if ctx.Err() != nil {
+				return candidates
+			}
+			attr := bodySchema.Attributes[name]
 
+			var declaredRng *hcl.Range
 			if !isAttributeDeclarable(body, name, attr) {
-				continue
+				declaredAttr, ok := body.Attributes[name]
+				if !ok || !d.decoderCtx.Completion.IncludeDeclaredAttributes || (attr.IsComputed && !attr.IsOptional) {
+					continue
+				}
+				rng, ok := d.attributeLinesRange(declaredAttr)
+				if !ok || rng.ContainsPos(editRng.Start) || rng.Overlaps(editRng) {
+					continue
+				}
+				declaredRng = &rng
 			}
-			if len(prefix) > 0 && !strings.HasPrefix(name, string(prefix)) {
+			penalty, ok := d.matchPrefix(name, string(prefix))
+			if !ok {
 				continue
 			}
-			if uint(count) >= d.maxCandidates {
-				return candidates
+			candidate := attributeSchemaToCandidate(ctx, name, attr, editRng, nestingLevel)
+			if declaredRng != nil {
+				// the attribute is re-inserted at the cursor
+				// and the existing declaration removed
+				candidate.AdditionalTextEdits = append(candidate.AdditionalTextEdits, lang.TextEdit{
+					Range: *declaredRng,
+				})
 			}
-
-			candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, name, attr, editRng))
-			count++
+			candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
 		}
-	} else if attr := schema.AnyAttribute; attr != nil && len(prefix) == 0 {
-		if uint(count) >= d.maxCandidates {
-			return candidates
+	} else if attr := bodySchema.AnyAttribute; attr != nil {
+		names := d.anyAttributeNamesFromTargets(body, attr)
+		for _, name := range names {
+			penalty, ok := d.matchPrefix(name, string(prefix))
+			if !ok {
+				continue
+			}
+			candidate := attributeSchemaToCandidate(ctx, name, attr, editRng, nestingLevel)
+			candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
 		}
+		if len(names) == 0 && len(prefix) == 0 {
+			name := "name"
+			if attr.NamePlaceholder != "" {
+				name = attr.NamePlaceholder
+			}
+			candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, name, attr, editRng, nestingLevel))
+		}
+	}
 
-		candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "name", attr, editRng))
-		count++
+	if d.decoderCtx.Completion.SuggestMissingRequiredAttributes && len(prefix) == 0 {
+		if candidate, ok := missingRequiredAttributesCandidate(ctx, body, bodySchema, editRng, nestingLevel); ok {
+			candidates.List = append(candidates.List, candidate)
+		}
 	}
 
-	blockTypes := sortedBlockTypes(schema.Blocks)
+	blockTypes := sortedBlockTypes(bodySchema.Blocks)
 	for _, bType := range blockTypes {
-		block := schema.Blocks[bType]
+		if ctx.Err() != nil {
+			return candidates
+		}
+		block := bodySchema.Blocks[bType]
 
 		// In Terraform duplicates should never occur when providers
 		// use the official plugin SDK, except when a field uses
@@ -82,31 +125,203 @@ func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.
 		// Here we prefer attribute completion in case of a duplicate
 		// to mimic how Terraform Core treats duplicate list(object)
 		// and set(object) attributes.
-		if _, ok := schema.Attributes[bType]; ok {
+		if _, ok := bodySchema.Attributes[bType]; ok {
 			continue
 		}
 
 		if !isBlockDeclarable(body, bType, block) {
 			continue
 		}
-		if len(prefix) > 0 && !strings.HasPrefix(bType, string(prefix)) {
+		penalty, ok := d.matchPrefix(bType, string(prefix))
+		if !ok {
 			continue
 		}
-		if uint(count) >= d.maxCandidates {
-			return candidates
+		candidate := d.blockSchemaToCandidate(ctx, bType, block, editRng)
+		candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
+
+		if bType == "dynamic" {
+			if ext := schema.BodyExtensionsFromContext(ctx); ext != nil && ext.DynamicBlocks {
+				for _, candidate := range dynamicBlockCandidates(bodySchema, block, editRng) {
+					candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
+				}
+				continue
+			}
 		}
 
-		candidates.List = append(candidates.List, d.blockSchemaToCandidate(bType, block, editRng))
-		count++
+		if d.decoderCtx.Completion.ExpandNestedBlockLabels && d.isNestedBody(body, editRng.Filename) {
+			for _, candidate := range labelPrefilledBlockCandidates(ctx, bType, block, editRng) {
+				candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
+			}
+		}
 	}
 
-	candidates.IsComplete = true
-
 	sort.Sort(candidates)
 
+	return d.truncateCandidates(candidates)
+}
+
+// attributeLinesRange returns the range of all lines of the attribute,
+// including any leading indentation and the trailing newline
+func (d *PathDecoder) attributeLinesRange(attr *hclsyntax.Attribute) (hcl.Range, bool) {
+	rng := attr.Range()
+	src, err := d.bytesForFile(rng.Filename)
+	if err != nil || rng.End.Byte > len(src) {
+		return hcl.Range{}, false
+	}
+
+	startByte := rng.Start.Byte
+	for startByte > 0 && src[startByte-1] != '\n' {
+		startByte--
+	}
+	start := hcl.Pos{
+		Line:   rng.Start.Line,
+		Column: 1,
+		Byte:   startByte,
+	}
+
+	end := rng.End
+	if idx := bytes.IndexByte(src[rng.End.Byte:], '\n'); idx >= 0 {
+		end = hcl.Pos{
+			Line:   rng.End.Line + 1,
+			Column: 1,
+			Byte:   rng.End.Byte + idx + 1,
+		}
+	}
+
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start:    start,
+		End:      end,
+	}, true
+}
+
+// isNestedBody reports whether the body belongs to a block,
+// as opposed to being the root body of the file
+func (d *PathDecoder) isNestedBody(body *hclsyntax.Body, filename string) bool {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return false
+	}
+	rootBody, ok := f.Body.(*hclsyntax.Body)
+	return ok && rootBody != body
+}
+
+// truncateCandidates limits the (sorted) candidates
+// to the first N, marking the list as incomplete
+// if any candidates were left out
+func (d *PathDecoder) truncateCandidates(candidates lang.Candidates) lang.Candidates {
+	limit := d.candidateLimit()
+	if uint(len(candidates.List)) > limit {
+		candidates.List = candidates.List[:limit]
+		candidates.IsComplete = false
+		return candidates
+	}
+
+	candidates.IsComplete = true
 	return candidates
 }
 
+// missingRequiredAttributesCandidate returns a candidate which inserts
+// all required attributes not yet declared in the body, each with
+// a placeholder value and its own tab stop.
+func missingRequiredAttributesCandidate(ctx context.Context, body *hclsyntax.Body, bodySchema *schema.BodySchema, rng hcl.Range, nestingLevel int) (lang.Candidate, bool) {
+	names := missingRequiredAttributes(body, bodySchema)
+	if len(names) == 0 {
+		return lang.Candidate{}, false
+	}
+
+	ctx = schema.WithPrefillRequiredFields(ctx, true)
+	separator := "\n" + strings.Repeat("  ", nestingLevel)
+
+	newTextLines := make([]string, 0, len(names))
+	snippetLines := make([]string, 0, len(names))
+	placeholder := 1
+	for _, name := range names {
+		cData := bodySchema.Attributes[name].Constraint.EmptyCompletionData(ctx, placeholder, nestingLevel)
+		newTextLines = append(newTextLines, fmt.Sprintf("%s = %s", name, cData.NewText))
+		snippetLines = append(snippetLines, fmt.Sprintf("%s = %s", name, cData.Snippet))
+
+		if cData.NextPlaceholder > placeholder {
+			placeholder = cData.NextPlaceholder
+		} else {
+			placeholder++
+		}
+	}
+
+	return lang.Candidate{
+		Label:  "required attributes",
+		Detail: fmt.Sprintf("%d missing: %s", len(names), strings.Join(names, ", ")),
+		Kind:   lang.AttributeCandidateKind,
+		TextEdit: lang.TextEdit{
+			NewText: strings.Join(newTextLines, separator),
+			Snippet: strings.Join(snippetLines, separator),
+			Range:   rng,
+		},
+	}, true
+}
+
+// IndentForPos returns the indentation (nesting) level of the body
+// enclosing the given position, i.e. the number of blocks
+// the position is nested in.
+//
+// This is used to indent continuation lines of multi-line snippets
+// inserted into nested bodies.
+func IndentForPos(body *hclsyntax.Body, pos hcl.Pos) int {
+	for _, block := range body.Blocks {
+		if block.Body == nil {
+			continue
+		}
+		if block.OpenBraceRange.End.Byte <= pos.Byte && pos.Byte <= block.CloseBraceRange.Start.Byte {
+			return 1 + IndentForPos(block.Body, pos)
+		}
+	}
+	return 0
+}
+
+// nestingLevelAtPos returns the nesting level of the body enclosing
+// the given position, or zero unless snippets are to be indented
+// (see IndentNestedSnippets)
+func (d *PathDecoder) nestingLevelAtPos(filename string, pos hcl.Pos) int {
+	if !d.decoderCtx.Completion.IndentNestedSnippets {
+		return 0
+	}
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return 0
+	}
+	rootBody, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return 0
+	}
+	return IndentForPos(rootBody, pos)
+}
+
+type nestingLevelCtxKey struct{}
+
+// withNestingLevel records the nesting level of the expression
+// being completed, for continuation lines of multi-line snippets
+// inside it to be indented accordingly
+func withNestingLevel(ctx context.Context, level int) context.Context {
+	return context.WithValue(ctx, nestingLevelCtxKey{}, level)
+}
+
+func nestingLevelFromContext(ctx context.Context) int {
+	level, _ := ctx.Value(nestingLevelCtxKey{}).(int)
+	return level
+}
+
+// nestedItemContext returns the context for completion of items
+// nested inside the expression being completed (e.g. object
+// attributes), along with their nesting level. The level
+// remains zero unless snippets are to be indented.
+func nestedItemContext(ctx context.Context) (context.Context, int) {
+	level, ok := ctx.Value(nestingLevelCtxKey{}).(int)
+	if !ok {
+		return ctx, 0
+	}
+	return withNestingLevel(ctx, level+1), level + 1
+}
+
 func sortedAttributeNames(attrs map[string]*schema.AttributeSchema) []string {
 	names := make([]string, len(attrs))
 	i := 0
@@ -129,6 +344,36 @@ func sortedBlockTypes(blocks map[string]*schema.BlockSchema) []string {
 	return bTypes
 }
 
+// anyAttributeNamesFromTargets returns sorted names of reference targets
+// in the scope declared via NameScopeId, which are not yet declared
+// in the body
+func (d *PathDecoder) anyAttributeNamesFromTargets(body *hclsyntax.Body, attr *schema.AttributeSchema) []string {
+	names := make([]string, 0)
+	if attr.NameScopeId == "" {
+		return names
+	}
+
+	seen := make(map[string]bool, 0)
+	for _, target := range d.pathCtx.ReferenceTargets {
+		if target.ScopeId != attr.NameScopeId || len(target.Addr) == 0 {
+			continue
+		}
+		name, ok := addressStepName(target.Addr[len(target.Addr)-1])
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if _, declared := body.Attributes[name]; declared {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 func isAttributeDeclarable(body *hclsyntax.Body, name string, attr *schema.AttributeSchema) bool {
 	if attr.IsComputed && !attr.IsOptional {
 		return false
@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -233,6 +234,74 @@ func TestDecoder_CandidateAtPos_incompleteBlocks(t *testing.T) {
 	}
 }
 
+func TestDecoder_CandidateAtPos_maxCandidates(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"zeta": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"alpha": {},
+			"beta":  {},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		maxCandidates      uint
+		expectedLabels     []string
+		expectedIsComplete bool
+	}{
+		{
+			"no limit configured",
+			0,
+			[]string{"alpha", "beta", "zeta"},
+			true,
+		},
+		{
+			"limit above count",
+			3,
+			[]string{"alpha", "beta", "zeta"},
+			true,
+		},
+		{
+			"truncated by sort order",
+			2,
+			[]string{"alpha", "beta"},
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.MaxCandidates = tc.maxCandidates
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.InitialPos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+			if candidates.IsComplete != tc.expectedIsComplete {
+				t.Fatalf("expected IsComplete: %t, got: %t", tc.expectedIsComplete, candidates.IsComplete)
+			}
+		})
+	}
+}
+
 func TestDecoder_CandidateAtPos_duplicateNames(t *testing.T) {
 	ctx := context.Background()
 	bodySchema := &schema.BodySchema{
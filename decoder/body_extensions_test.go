@@ -257,7 +257,22 @@ func TestCompletionAtPos_BodySchema_Extensions_Count(t *testing.T) {
 				Column: 8,
 				Byte:   44,
 			},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 44},
+							End:      hcl.Pos{Line: 2, Column: 8, Byte: 44},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"count.index value completion",
@@ -348,6 +363,100 @@ func TestCompletionAtPos_BodySchema_Extensions_Count(t *testing.T) {
 					},
 					Kind: lang.ReferenceCandidateKind,
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 15, Byte: 57},
+							End:      hcl.Pos{Line: 3, Column: 15, Byte: 57},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
+		},
+		{
+			"count.index completion after count prefix",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{
+								Name: "type",
+							},
+							{
+								Name: "name",
+							},
+						},
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"cpu_count": {
+									IsOptional: true,
+									Constraint: schema.Reference{
+										OfType: cty.Number,
+									},
+								},
+							},
+							Extensions: &schema.BodyExtensions{
+								Count: true,
+							},
+						},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					LocalAddr: lang.Address{
+						lang.RootStep{Name: "count"},
+						lang.AttrStep{Name: "index"},
+					},
+					TargetableFromRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
+						End:      hcl.Pos{Line: 4, Column: 2, Byte: 66},
+					},
+					Type:        cty.Number,
+					Description: lang.PlainText("The distinct index number (starting with 0) corresponding to the instance"),
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+						End:      hcl.Pos{Line: 2, Column: 12, Byte: 43},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+						End:      hcl.Pos{Line: 2, Column: 8, Byte: 39},
+					},
+				},
+			},
+			`resource "aws_instance" "foo" {
+  count = 4
+  cpu_count = count.
+}`,
+			hcl.Pos{Line: 3, Column: 21, Byte: 64},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "count.index",
+					Description: lang.MarkupContent{
+						Value: "The distinct index number (starting with 0) corresponding to the instance",
+						Kind:  lang.PlainTextKind,
+					},
+					Detail: "number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 15, Byte: 58},
+							End:      hcl.Pos{Line: 3, Column: 21, Byte: 64},
+						},
+						NewText: "count.index",
+						Snippet: "count.index",
+					},
+					Kind: lang.ReferenceCandidateKind,
+				},
 			}),
 		},
 		{
@@ -421,7 +530,22 @@ func TestCompletionAtPos_BodySchema_Extensions_Count(t *testing.T) {
 				Column: 8,
 				Byte:   44,
 			},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 44},
+							End:      hcl.Pos{Line: 2, Column: 8, Byte: 44},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"count.index completes when inside nested blocks",
@@ -520,6 +644,20 @@ func TestCompletionAtPos_BodySchema_Extensions_Count(t *testing.T) {
 					},
 					Kind: lang.ReferenceCandidateKind,
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 17, Byte: 67},
+							End:      hcl.Pos{Line: 4, Column: 17, Byte: 67},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -601,6 +739,20 @@ variable "test" {
 					},
 					Kind: lang.ReferenceCandidateKind,
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 11, Byte: 42},
+							End:      hcl.Pos{Line: 2, Column: 11, Byte: 42},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 	}
@@ -812,6 +964,20 @@ thing =
 						Snippet: "each.value",
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 9, Byte: 102},
+							End:      hcl.Pos{Line: 6, Column: 9, Byte: 102},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -843,7 +1009,22 @@ thing =
 thing = 
 }`,
 			hcl.Pos{Line: 2, Column: 9, Byte: 40},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 9, Byte: 40},
+							End:      hcl.Pos{Line: 2, Column: 9, Byte: 40},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"each.* does not complete when extension not enabled",
@@ -874,7 +1055,22 @@ thing =
 	thing = 
 }`,
 			hcl.Pos{Line: 2, Column: 8, Byte: 41},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 41},
+							End:      hcl.Pos{Line: 2, Column: 8, Byte: 41},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"for_each does not complete more than once",
@@ -1042,6 +1238,20 @@ foo {
 						Snippet: "each.value",
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 7, Column: 13, Byte: 109},
+							End:      hcl.Pos{Line: 7, Column: 13, Byte: 109},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -1116,6 +1326,20 @@ for_each =
 					},
 					Kind: lang.ObjectCandidateKind,
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 12, Byte: 43},
+							End:      hcl.Pos{Line: 2, Column: 12, Byte: 43},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 	}
@@ -1221,7 +1445,22 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
   fox =
 }`,
 			hcl.Pos{Line: 3, Column: 8, Byte: 55},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 8, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 8, Byte: 55},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"target self addr enabled and extension enabled",
@@ -1307,7 +1546,22 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self",
 						Snippet: "self",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:                lang.ReferenceCandidateKind,
+					TriggerSuggestChars: []string{"."},
+				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 8, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 8, Byte: 55},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
 				},
 			}),
 		},
@@ -1380,7 +1634,22 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
   fox =
 }`,
 			hcl.Pos{Line: 3, Column: 8, Byte: 55},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 8, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 8, Byte: 55},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"no cyclical completion (attr = self.attr)",
@@ -1440,7 +1709,22 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
   cpu_count = 
 }`,
 			hcl.Pos{Line: 2, Column: 15, Byte: 46},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 46},
+							End:      hcl.Pos{Line: 2, Column: 15, Byte: 46},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"completion with prefix",
@@ -1623,7 +1907,22 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self",
 						Snippet: "self",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:                lang.ReferenceCandidateKind,
+					TriggerSuggestChars: []string{"."},
+				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 10, Byte: 68},
+							End:      hcl.Pos{Line: 4, Column: 10, Byte: 68},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
 				},
 			}),
 		},
@@ -1696,6 +1995,20 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 					},
 					Kind: lang.ReferenceCandidateKind,
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 14, Byte: 33},
+							End:      hcl.Pos{Line: 3, Column: 14, Byte: 33},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -1907,7 +2220,7 @@ resource "aws_elastic_beanstalk_environment" "example" {
 			"",
 		},
 		{
-			"dynamic block completion",
+			"dynamic block completion skips single blocks",
 			&schema.BodySchema{
 				Blocks: map[string]*schema.BlockSchema{
 					"resource": {
@@ -1921,8 +2234,6 @@ resource "aws_elastic_beanstalk_environment" "example" {
 							Extensions: &schema.BodyExtensions{
 								DynamicBlocks: true,
 							},
-							Blocks:     make(map[string]*schema.BlockSchema, 0),
-							Attributes: make(map[string]*schema.AttributeSchema, 0),
 						},
 						DependentBody: map[schema.SchemaKey]*schema.BodySchema{
 							schema.NewSchemaKey(schema.DependencyKeys{
@@ -1934,11 +2245,9 @@ resource "aws_elastic_beanstalk_environment" "example" {
 									"foo": {
 										Body: schema.NewBodySchema(),
 									},
-								},
-								Attributes: map[string]*schema.AttributeSchema{
-									"instance_size": {
-										IsOptional: true,
-										Constraint: schema.LiteralType{Type: cty.String},
+									"single": {
+										MaxItems: 1,
+										Body:     schema.NewBodySchema(),
 									},
 								},
 							},
@@ -1947,10 +2256,9 @@ resource "aws_elastic_beanstalk_environment" "example" {
 				},
 			},
 			`resource "aws_instance" "example" {
-	name = "example"
-	
+  dy
 }`,
-			hcl.Pos{Line: 3, Column: 3, Byte: 55},
+			hcl.Pos{Line: 2, Column: 5, Byte: 40},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label: "dynamic",
@@ -1958,43 +2266,149 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:              "Block",
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
-							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 38},
+							End:      hcl.Pos{Line: 2, Column: 5, Byte: 40},
 						},
 						NewText: "dynamic",
 						Snippet: "dynamic \"${1}\" {\n  ${2}\n}",
 					},
 				},
 				{
-					Label:  "foo",
+					Label:  `dynamic "foo"`,
 					Detail: "Block",
-					Kind:   lang.BlockCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "Produces `foo` blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Kind: lang.BlockCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
-							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 38},
+							End:      hcl.Pos{Line: 2, Column: 5, Byte: 40},
 						},
-						NewText: "foo",
-						Snippet: "foo {\n  ${1}\n}",
+						NewText: `dynamic "foo"`,
+						Snippet: "dynamic \"foo\" {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}",
 					},
 				},
-				{
-					Label:  "instance_size",
-					Detail: "optional, string",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
-							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
-						},
+			}),
+			"",
+		},
+		{
+			"dynamic block completion",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{
+								Name:     "type",
+								IsDepKey: true,
+							}, {Name: "name"},
+						},
+						Body: &schema.BodySchema{
+							Extensions: &schema.BodyExtensions{
+								DynamicBlocks: true,
+							},
+							Blocks:     make(map[string]*schema.BlockSchema, 0),
+							Attributes: make(map[string]*schema.AttributeSchema, 0),
+						},
+						DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+							schema.NewSchemaKey(schema.DependencyKeys{
+								Labels: []schema.LabelDependent{
+									{Index: 0, Value: "aws_instance"},
+								},
+							}): {
+								Blocks: map[string]*schema.BlockSchema{
+									"foo": {
+										Body: schema.NewBodySchema(),
+									},
+								},
+								Attributes: map[string]*schema.AttributeSchema{
+									"instance_size": {
+										IsOptional: true,
+										Constraint: schema.LiteralType{Type: cty.String},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" "example" {
+	name = "example"
+	
+}`,
+			hcl.Pos{Line: 3, Column: 3, Byte: 55},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "dynamic",
+					Description: lang.MarkupContent{
+						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail:              "Block",
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
+						},
+						NewText: "dynamic",
+						Snippet: "dynamic \"${1}\" {\n  ${2}\n}",
+					},
+				},
+				{
+					Label:  `dynamic "foo"`,
+					Detail: "Block",
+					Description: lang.MarkupContent{
+						Value: "Produces `foo` blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Kind: lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
+						},
+						NewText: `dynamic "foo"`,
+						Snippet: "dynamic \"foo\" {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}",
+					},
+				},
+				{
+					Label:  "foo",
+					Detail: "Block",
+					Kind:   lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
+						},
+						NewText: "foo",
+						Snippet: "foo {\n  ${1}\n}",
+					},
+				},
+				{
+					Label:  "instance_size",
+					Detail: "optional, string",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 55},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 55},
+						},
 						NewText: "instance_size",
 						Snippet: `instance_size = "${1:value}"`,
 					},
@@ -2389,9 +2803,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:              "Block",
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2402,6 +2817,24 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Snippet: "dynamic \"${1}\" {\n  ${2}\n}",
 					},
 				},
+				{
+					Label:  `dynamic "bar"`,
+					Detail: "Block",
+					Description: lang.MarkupContent{
+						Value: "Produces `bar` blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Kind: lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 7, Byte: 86},
+							End:      hcl.Pos{Line: 5, Column: 7, Byte: 86},
+						},
+						NewText: `dynamic "bar"`,
+						Snippet: "dynamic \"bar\" {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}",
+					},
+				},
 				{
 					Label:  "thing",
 					Detail: "optional, string",
@@ -2504,6 +2937,20 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Snippet: `true`,
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 15, Byte: 94},
+							End:      hcl.Pos{Line: 5, Column: 15, Byte: 94},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 			"",
 		},
@@ -2554,9 +3001,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:              "Block",
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2567,6 +3015,24 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Snippet: "dynamic \"${1}\" {\n  ${2}\n}",
 					},
 				},
+				{
+					Label:  `dynamic "foo"`,
+					Detail: "Block",
+					Description: lang.MarkupContent{
+						Value: "Produces `foo` blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Kind: lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 3, Byte: 78},
+							End:      hcl.Pos{Line: 6, Column: 3, Byte: 78},
+						},
+						NewText: `dynamic "foo"`,
+						Snippet: "dynamic \"foo\" {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}",
+					},
+				},
 				{
 					Label:  "foo",
 					Detail: "Block",
@@ -2656,9 +3122,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:              "Block",
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2669,6 +3136,24 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Snippet: "dynamic \"${1}\" {\n  ${2}\n}",
 					},
 				},
+				{
+					Label:  `dynamic "bar"`,
+					Detail: "Block",
+					Description: lang.MarkupContent{
+						Value: "Produces `bar` blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Kind: lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 63},
+							End:      hcl.Pos{Line: 4, Column: 5, Byte: 63},
+						},
+						NewText: `dynamic "bar"`,
+						Snippet: "dynamic \"bar\" {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}",
+					},
+				},
 			}),
 			"",
 		},
@@ -2814,9 +3299,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:              "Block",
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2827,6 +3313,24 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Snippet: "dynamic \"${1}\" {\n  ${2}\n}",
 					},
 				},
+				{
+					Label:  `dynamic "baz"`,
+					Detail: "Block",
+					Description: lang.MarkupContent{
+						Value: "Produces `baz` blocks dynamically by iterating over a given complex value",
+						Kind:  lang.MarkdownKind,
+					},
+					Kind: lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 7, Byte: 60},
+							End:      hcl.Pos{Line: 4, Column: 7, Byte: 60},
+						},
+						NewText: `dynamic "baz"`,
+						Snippet: "dynamic \"baz\" {\n  for_each = ${1}\n  content {\n    ${2}\n  }\n}",
+					},
+				},
 			}),
 			"",
 		},
@@ -2862,3 +3366,591 @@ resource "aws_elastic_beanstalk_environment" "example" {
 		})
 	}
 }
+
+func TestCompletionAtPos_BodySchema_Extensions_CountForEachConflict(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"}, {Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Count:   true,
+						ForEach: true,
+					},
+					Attributes: map[string]*schema.AttributeSchema{
+						"thing": {
+							IsOptional: true,
+							Constraint: schema.Reference{
+								OfType: cty.String,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"for_each suppresses count",
+			`resource "aws_instance" "foo" {
+  for_each = { a = "b" }
+
+}`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 57},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "thing",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 57},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 57},
+						},
+						NewText: "thing",
+						Snippet: "thing = ",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"count suppresses for_each",
+			`resource "aws_instance" "foo" {
+  count = 2
+
+}`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 44},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "thing",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 44},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 44},
+						},
+						NewText: "thing",
+						Snippet: "thing = ",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"for_each enables each.key",
+			`resource "aws_instance" "foo" {
+  for_each = { a = "b" }
+  thing = 
+}`,
+			hcl.Pos{Line: 3, Column: 11, Byte: 67},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "each.key",
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "The map key (or set member) corresponding to this instance",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 67},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 67},
+						},
+						NewText: "each.key",
+						Snippet: "each.key",
+					},
+				},
+				{
+					Label:  "each.value",
+					Detail: "dynamic",
+					Kind:   lang.ReferenceCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "The map value corresponding to this instance. (If a set was provided, this is the same as `each.key`.)",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 67},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 67},
+						},
+						NewText: "each.value",
+						Snippet: "each.value",
+					},
+				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 67},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 67},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			targets, err := d.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d = testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_BodySchema_Extensions_DynamicBlockIterator(t *testing.T) {
+	ctx := context.Background()
+
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:     "type",
+						IsDepKey: true,
+					}, {Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						DynamicBlocks: true,
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "aws_instance"},
+						},
+					}): {
+						Blocks: map[string]*schema.BlockSchema{
+							"foo": {
+								Body: &schema.BodySchema{
+									Attributes: map[string]*schema.AttributeSchema{
+										"bar": {
+											IsOptional: true,
+											Constraint: schema.Reference{OfType: cty.String},
+										},
+									},
+								},
+							},
+						},
+						Attributes: map[string]*schema.AttributeSchema{
+							"baz": {
+								IsOptional: true,
+								Constraint: schema.Reference{OfType: cty.String},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"iterator named after label",
+			`resource "aws_instance" "example" {
+  dynamic "foo" {
+    for_each = {}
+    content {
+      bar = foo.
+    }
+  }
+}`,
+			hcl.Pos{Line: 5, Column: 17, Byte: 102},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "foo.key",
+					Detail: "dynamic",
+					Kind:   lang.ReferenceCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "The map key or list element index for the current element",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 13, Byte: 98},
+							End:      hcl.Pos{Line: 5, Column: 17, Byte: 102},
+						},
+						NewText: "foo.key",
+						Snippet: "foo.key",
+					},
+				},
+				{
+					Label:  "foo.value",
+					Detail: "dynamic",
+					Kind:   lang.ReferenceCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "The value of the current element",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 13, Byte: 98},
+							End:      hcl.Pos{Line: 5, Column: 17, Byte: 102},
+						},
+						NewText: "foo.value",
+						Snippet: "foo.value",
+					},
+				},
+			}),
+		},
+		{
+			"iterator named via iterator attribute",
+			`resource "aws_instance" "example" {
+  dynamic "foo" {
+    for_each = {}
+    iterator = it
+    content {
+      bar = it.
+    }
+  }
+}`,
+			hcl.Pos{Line: 6, Column: 16, Byte: 119},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "it.key",
+					Detail: "dynamic",
+					Kind:   lang.ReferenceCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "The map key or list element index for the current element",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 13, Byte: 116},
+							End:      hcl.Pos{Line: 6, Column: 16, Byte: 119},
+						},
+						NewText: "it.key",
+						Snippet: "it.key",
+					},
+				},
+				{
+					Label:  "it.value",
+					Detail: "dynamic",
+					Kind:   lang.ReferenceCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "The value of the current element",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 13, Byte: 116},
+							End:      hcl.Pos{Line: 6, Column: 16, Byte: 119},
+						},
+						NewText: "it.value",
+						Snippet: "it.value",
+					},
+				},
+			}),
+		},
+		{
+			"iterator not available outside of content",
+			`resource "aws_instance" "example" {
+  dynamic "foo" {
+    for_each = {}
+    content {
+    }
+  }
+  baz = foo.
+}`,
+			hcl.Pos{Line: 7, Column: 13, Byte: 108},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			targets, err := d.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d = testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_BodySchema_Extensions_DependsOn(t *testing.T) {
+	ctx := context.Background()
+
+	addressableBlock := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "type"}, {Name: "name"},
+		},
+		Address: &schema.BlockAddrSchema{
+			Steps: []schema.AddrStep{
+				schema.LabelStep{Index: 0},
+				schema.LabelStep{Index: 1},
+			},
+			AsReference: true,
+			ScopeId:     lang.ScopeId("resource"),
+		},
+		Body: &schema.BodySchema{
+			Extensions: &schema.BodyExtensions{
+				DependsOn:         true,
+				DependsOnScopeIds: []lang.ScopeId{"resource"},
+			},
+		},
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": addressableBlock,
+			"data": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"}, {Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.StaticStep{Name: "data"},
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					AsReference: true,
+					ScopeId:     lang.ScopeId("data"),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"depends_on attribute",
+			`resource "aws_instance" "foo" {
+  
+}`,
+			hcl.Pos{Line: 2, Column: 3, Byte: 34},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "depends_on",
+					Detail: "optional, list of block address",
+					Kind:   lang.AttributeCandidateKind,
+					Description: lang.MarkupContent{
+						Value: "A meta-argument that declares explicit dependencies on other blocks, which cannot be inferred from references in the configuration.",
+						Kind:  lang.MarkdownKind,
+					},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+							End:      hcl.Pos{Line: 2, Column: 3, Byte: 34},
+						},
+						NewText: "depends_on",
+						Snippet: "depends_on = [ ${1} ]",
+					},
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"block addresses excluding self",
+			`resource "aws_instance" "foo" {
+  depends_on = [  ]
+}
+resource "aws_instance" "bar" {
+}
+resource "aws_eip" "baz" {
+}`,
+			hcl.Pos{Line: 2, Column: 17, Byte: 48},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_eip.baz",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 17, Byte: 48},
+							End:      hcl.Pos{Line: 2, Column: 17, Byte: 48},
+						},
+						NewText: "aws_eip.baz",
+						Snippet: "aws_eip.baz",
+					},
+				},
+				{
+					Label:  "aws_instance.bar",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 17, Byte: 48},
+							End:      hcl.Pos{Line: 2, Column: 17, Byte: 48},
+						},
+						NewText: "aws_instance.bar",
+						Snippet: "aws_instance.bar",
+					},
+				},
+			}),
+		},
+		{
+			"block addresses of other scopes excluded",
+			`resource "aws_instance" "foo" {
+  depends_on = [ aws ]
+}
+resource "aws_eip" "baz" {
+}
+data "aws_ami" "qux" {
+}`,
+			hcl.Pos{Line: 2, Column: 21, Byte: 52},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_eip.baz",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 18, Byte: 49},
+							End:      hcl.Pos{Line: 2, Column: 21, Byte: 52},
+						},
+						NewText: "aws_eip.baz",
+						Snippet: "aws_eip.baz",
+					},
+				},
+			}),
+		},
+		{
+			"block addresses with prefix and declared address",
+			`resource "aws_instance" "foo" {
+  depends_on = [ aws_eip.baz, aws_i ]
+}
+resource "aws_instance" "bar" {
+}
+resource "aws_eip" "baz" {
+}`,
+			hcl.Pos{Line: 2, Column: 36, Byte: 67},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_instance.bar",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 31, Byte: 62},
+							End:      hcl.Pos{Line: 2, Column: 36, Byte: 67},
+						},
+						NewText: "aws_instance.bar",
+						Snippet: "aws_instance.bar",
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			targets, err := d.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d = testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
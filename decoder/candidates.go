@@ -4,6 +4,7 @@
 package decoder
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -14,6 +15,19 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+type manualCompletionCtxKey struct{}
+
+// WithManualCompletion marks the completion request as invoked
+// explicitly by the user (e.g. via Ctrl+Space), as opposed
+// to being triggered automatically while typing.
+func WithManualCompletion(ctx context.Context) context.Context {
+	return context.WithValue(ctx, manualCompletionCtxKey{}, true)
+}
+
+func isManualCompletion(ctx context.Context) bool {
+	return ctx.Value(manualCompletionCtxKey{}) != nil
+}
+
 // CompletionAtPos returns completion candidates for a given position in a file
 //
 // Schema is required in order to return any candidates and method will return
@@ -43,8 +57,48 @@ func (d *PathDecoder) CompletionAtPos(ctx context.Context, filename string, pos
 	}
 
 	ctx = schema.WithPrefillRequiredFields(ctx, d.PrefillRequiredFields)
+	ctx = withSignatureHelpCommandID(ctx, d.decoderCtx.Completion.SignatureHelpCommandID)
+	ctx = withDescriptionResolveHook(ctx, lang.ResolveHook{
+		Name:       descriptionResolveHookName,
+		Path:       d.path.Path,
+		LanguageID: d.path.LanguageID,
+		Filename:   filename,
+		Pos:        &pos,
+	})
+
+	candidates, err := d.completionAtPos(ctx, rootBody, outerBodyRng, d.pathCtx.Schema, pos)
+	if err != nil {
+		return candidates, err
+	}
+	// candidate collection may have been cut short
+	// if the request was cancelled in the meantime
+	if err := ctx.Err(); err != nil {
+		return lang.ZeroCandidates(), err
+	}
 
-	return d.completionAtPos(ctx, rootBody, outerBodyRng, d.pathCtx.Schema, pos)
+	if d.decoderCtx.EmptyFileScaffold != nil && len(bytes.TrimSpace(f.Bytes)) == 0 {
+		candidates.List = append(candidates.List, scaffoldCandidate(d.decoderCtx.EmptyFileScaffold, filename, pos))
+	}
+
+	return candidates, nil
+}
+
+func scaffoldCandidate(scaffold *Scaffold, filename string, pos hcl.Pos) lang.Candidate {
+	return lang.Candidate{
+		Label:       scaffold.Label,
+		Detail:      scaffold.Detail,
+		Description: scaffold.Description,
+		Kind:        lang.BlockCandidateKind,
+		TextEdit: lang.TextEdit{
+			NewText: scaffold.NewText,
+			Snippet: scaffold.Snippet,
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    pos,
+				End:      pos,
+			},
+		},
+	}
 }
 
 func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body, outerBodyRng hcl.Range, bodySchema *schema.BodySchema, pos hcl.Pos) (lang.Candidates, error) {
@@ -53,18 +107,26 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 	}
 
 	filename := body.Range().Filename
+	ctx = schema.WithBodyExtensions(ctx, bodySchema.Extensions)
+	ext := schema.BodyExtensionsFromContext(ctx)
 
 	for _, attr := range body.Attributes {
+		if err := ctx.Err(); err != nil {
+			return lang.ZeroCandidates(), err
+		}
 		if d.isPosInsideAttrExpr(attr, pos) {
-			if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
+			if ext != nil && ext.SelfRefs {
 				ctx = schema.WithActiveSelfRefs(ctx)
 			}
-			if bodySchema.Extensions != nil && bodySchema.Extensions.Count && attr.Name == "count" {
+			if ext != nil && ext.Count && attr.Name == "count" {
 				return d.attrValueCompletionAtPos(ctx, attr, schemahelper.CountAttributeSchema(), outerBodyRng, pos)
 			}
-			if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && attr.Name == "for_each" {
+			if ext != nil && ext.ForEach && attr.Name == "for_each" {
 				return d.attrValueCompletionAtPos(ctx, attr, schemahelper.ForEachAttributeSchema(), outerBodyRng, pos)
 			}
+			if ext != nil && ext.DependsOn && attr.Name == "depends_on" {
+				return d.dependsOnCompletionAtPos(ctx, attr, ext.DependsOnScopeIds, outerBodyRng, pos)
+			}
 			if aSchema, ok := bodySchema.Attributes[attr.Name]; ok {
 				return d.attrValueCompletionAtPos(ctx, attr, aSchema, outerBodyRng, pos)
 			}
@@ -91,6 +153,9 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 	}
 
 	for _, block := range body.Blocks {
+		if err := ctx.Err(); err != nil {
+			return lang.ZeroCandidates(), err
+		}
 		if block.Range().ContainsPos(pos) {
 			blockSchema, ok := bodySchema.Blocks[block.Type]
 			if !ok {
@@ -126,11 +191,15 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 
 					labelSchema := blockSchema.Labels[i]
 
+					if labelSchema.CompletionTargets != nil {
+						return d.labelCandidatesFromReferenceTargets(ctx, *labelSchema.CompletionTargets, prefixRng, rng)
+					}
+
 					if !labelSchema.Completable {
 						return lang.ZeroCandidates(), nil
 					}
 
-					return d.labelCandidatesFromDependentSchema(i, blockSchema.DependentBody, prefixRng, rng, block, blockSchema.Labels)
+					return d.labelCandidatesFromDependentSchema(ctx, i, blockSchema.DependentBody, prefixRng, rng, block, blockSchema.Labels)
 				}
 			}
 
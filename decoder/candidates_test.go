@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -441,6 +442,81 @@ func TestDecoder_CompletionAtPos_invalidBlockPositions(t *testing.T) {
 	}
 }
 
+func TestDecoder_CompletionAtPos_afterOpeningBraceSameLine(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"num_attr": {Constraint: schema.LiteralType{Type: cty.Number}},
+					},
+				},
+			},
+		},
+	}
+	testConfig := []byte(`myblock "foo" { 
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testConfig, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	testCases := []struct {
+		name string
+		pos  hcl.Pos
+	}{
+		{
+			"directly after brace",
+			hcl.Pos{Line: 1, Column: 16, Byte: 15},
+		},
+		{
+			"after brace and whitespace",
+			hcl.Pos{Line: 1, Column: 17, Byte: 16},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "num_attr",
+					Detail: "number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    tc.pos,
+							End:      tc.pos,
+						},
+						NewText: "num_attr",
+						Snippet: "num_attr = ${1:0}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			})
+			if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_CompletionAtPos_rightHandSide(t *testing.T) {
 	ctx := context.Background()
 	resourceLabelSchema := []*schema.LabelSchema{
@@ -740,8 +816,9 @@ func TestDecoder_CompletionAtPos_zeroByteContent(t *testing.T) {
 				NewText: "resource",
 				Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 			},
-			Kind:           lang.BlockCandidateKind,
-			TriggerSuggest: true,
+			Kind:                lang.BlockCandidateKind,
+			TriggerSuggest:      true,
+			TriggerSuggestChars: []string{`"`},
 		},
 	})
 	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
@@ -749,6 +826,119 @@ func TestDecoder_CompletionAtPos_zeroByteContent(t *testing.T) {
 	}
 }
 
+func TestDecoder_CompletionAtPos_emptyFileScaffold(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"terraform": {
+				Body: schema.NewBodySchema(),
+			},
+		},
+	}
+	scaffold := &Scaffold{
+		Label:   "scaffold",
+		Detail:  "Minimal configuration",
+		NewText: "terraform {\n  \n}",
+		Snippet: "terraform {\n  ${1}\n}",
+	}
+	blockCandidate := func(rng hcl.Range) lang.Candidate {
+		return lang.Candidate{
+			Label:  "terraform",
+			Detail: "Block",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "terraform",
+				Snippet: "terraform {\n  ${1}\n}",
+			},
+			Kind: lang.BlockCandidateKind,
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"empty file",
+			``,
+			hcl.InitialPos,
+			lang.CompleteCandidates([]lang.Candidate{
+				blockCandidate(hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.InitialPos,
+					End:      hcl.InitialPos,
+				}),
+				{
+					Label:  "scaffold",
+					Detail: "Minimal configuration",
+					Kind:   lang.BlockCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.InitialPos,
+							End:      hcl.InitialPos,
+						},
+						NewText: "terraform {\n  \n}",
+						Snippet: "terraform {\n  ${1}\n}",
+					},
+				},
+			}),
+		},
+		{
+			"non-empty file",
+			`terraform {}
+`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 13},
+			lang.CompleteCandidates([]lang.Candidate{
+				blockCandidate(hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 13},
+					End:      hcl.Pos{Line: 2, Column: 1, Byte: 13},
+				}),
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+
+			dirPath := t.TempDir()
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Schema: bodySchema,
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+					},
+				},
+			})
+			decoderCtx := NewDecoderContext()
+			decoderCtx.EmptyFileScaffold = scaffold
+			d.SetContext(decoderCtx)
+
+			pathDecoder, err := d.Path(lang.Path{Path: dirPath})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			candidates, err := pathDecoder.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_CompletionAtPos_endOfFilePos(t *testing.T) {
 	ctx := context.Background()
 	resourceLabelSchema := []*schema.LabelSchema{
@@ -804,8 +994,9 @@ func TestDecoder_CompletionAtPos_endOfFilePos(t *testing.T) {
 					NewText: "resource",
 					Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 				},
-				Kind:           lang.BlockCandidateKind,
-				TriggerSuggest: true,
+				Kind:                lang.BlockCandidateKind,
+				TriggerSuggest:      true,
+				TriggerSuggestChars: []string{`"`},
 			},
 		},
 		IsComplete: true,
@@ -1005,6 +1196,204 @@ func TestDecoder_CompletionAtPos_emptyLabel_duplicateDepKeys(t *testing.T) {
 	}
 }
 
+func TestDecoder_CompletionAtPos_emptyLabel_partialDepKeys(t *testing.T) {
+	ctx := context.Background()
+	blockSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "provider", IsDepKey: true, Completable: true},
+			{Name: "type", IsDepKey: true, Completable: true},
+		},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			schema.NewSchemaKey(schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "aws"},
+					{Index: 1, Value: "instance"},
+				},
+			}): {},
+			schema.NewSchemaKey(schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "aws"},
+					{Index: 1, Value: "bucket"},
+				},
+			}): {},
+			schema.NewSchemaKey(schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "google"},
+					{Index: 1, Value: "compute"},
+				},
+			}): {},
+		},
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": blockSchema,
+		},
+	}
+
+	cfg := []byte(`resource "aws" "" {
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 17, Byte: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	editRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+		End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label: "bucket",
+			TextEdit: lang.TextEdit{
+				Range:   editRng,
+				NewText: "bucket",
+				Snippet: "bucket",
+			},
+			Kind: lang.LabelCandidateKind,
+		},
+		{
+			Label: "instance",
+			TextEdit: lang.TextEdit{
+				Range:   editRng,
+				NewText: "instance",
+				Snippet: "instance",
+			},
+			Kind: lang.LabelCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_attributeDependentBody(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"provider": {
+							Constraint: schema.Reference{OfType: cty.DynamicPseudoType},
+							IsDepKey:   true,
+						},
+						"region": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsDepKey:   true,
+						},
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Attributes: []schema.AttributeDependent{
+							{
+								Name: "provider",
+								Expr: schema.ExpressionValue{
+									Address: lang.Address{
+										lang.RootStep{Name: "azurerm"},
+									},
+								},
+							},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"location": {Constraint: schema.LiteralType{Type: cty.String}},
+						},
+					},
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Attributes: []schema.AttributeDependent{
+							{
+								Name: "region",
+								Expr: schema.ExpressionValue{
+									Static: cty.StringVal("eu"),
+								},
+							},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"gdpr": {Constraint: schema.LiteralType{Type: cty.Bool}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName       string
+		cfg            string
+		expectedLabels []string
+	}{
+		{
+			"no dependent attribute",
+			"resource {\n  \n}\n",
+			[]string{"provider", "region"},
+		},
+		{
+			"matching reference",
+			"resource {\n  provider = azurerm\n  \n}\n",
+			[]string{"location", "region"},
+		},
+		{
+			"mismatching reference",
+			"resource {\n  provider = google\n  \n}\n",
+			[]string{"region"},
+		},
+		{
+			"matching static value",
+			"resource {\n  region = \"eu\"\n  \n}\n",
+			[]string{"gdpr", "provider"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, pDiags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			if len(pDiags) > 0 {
+				t.Fatal(pDiags)
+			}
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			// position on the blank line right before the closing brace
+			lines := strings.Split(tc.cfg, "\n")
+			line := len(lines) - 2
+			byteOffset := len(strings.Join(lines[:line-1], "\n")) + 1 + 2
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: line, Column: 3, Byte: byteOffset})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_CompletionAtPos_basic(t *testing.T) {
 	ctx := context.Background()
 	resourceLabelSchema := []*schema.LabelSchema{
@@ -1106,8 +1495,9 @@ resource "random_resource" "test" {
 						NewText: "resource",
 						Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 					},
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 				},
 			}),
 		},
@@ -1127,8 +1517,9 @@ resource "random_resource" "test" {
 						NewText: "resource",
 						Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 					},
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Kind:                lang.BlockCandidateKind,
+					TriggerSuggest:      true,
+					TriggerSuggestChars: []string{`"`},
 				},
 			}),
 		},
@@ -1425,18 +1816,668 @@ func TestDecoder_CompletionAtPos_AnyAttribute(t *testing.T) {
 	}
 }
 
-func TestDecoder_CompletionAtPos_multipleTypes(t *testing.T) {
+func TestDecoder_CompletionAtPos_AnyAttribute_namePlaceholder(t *testing.T) {
 	ctx := context.Background()
-	resourceLabelSchema := []*schema.LabelSchema{
-		{Name: "type", IsDepKey: true, Completable: true},
-		{Name: "name"},
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"required_providers": {
+				Body: &schema.BodySchema{
+					AnyAttribute: &schema.AttributeSchema{
+						Constraint:      schema.LiteralType{Type: cty.String},
+						NamePlaceholder: "provider_name",
+					},
+				},
+			},
+		},
 	}
 
-	resourceSchema := &schema.BlockSchema{
-		Labels: resourceLabelSchema,
-		Body: &schema.BodySchema{
-			Attributes: map[string]*schema.AttributeSchema{
-				"for_each": {
+	cfg := []byte(`required_providers {
+
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	pos := hcl.Pos{Line: 2, Column: 1, Byte: 21}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "provider_name",
+			Detail: "string",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 21},
+					End:      hcl.Pos{Line: 2, Column: 1, Byte: 21},
+				},
+				NewText: "provider_name",
+				Snippet: "provider_name = \"${1:value}\"",
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+	})
+
+	diff := cmp.Diff(expectedCandidates, candidates, ctydebug.CmpOptions)
+	if diff != "" {
+		t.Fatalf("unexpected schema for %s: %s", stringPos(pos), diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_AnyAttribute_namesFromTargets(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"required_providers": {
+				Body: &schema.BodySchema{
+					AnyAttribute: &schema.AttributeSchema{
+						Constraint:      schema.LiteralType{Type: cty.String},
+						NamePlaceholder: "provider_name",
+						NameScopeId:     lang.ScopeId("provider"),
+					},
+				},
+			},
+		},
+	}
+	providerTarget := func(name string) reference.Target {
+		return reference.Target{
+			Addr: lang.Address{
+				lang.RootStep{Name: "provider"},
+				lang.AttrStep{Name: name},
+			},
+			ScopeId: lang.ScopeId("provider"),
+		}
+	}
+
+	testCases := []struct {
+		testName       string
+		cfg            string
+		pos            hcl.Pos
+		targets        reference.Targets
+		expectedLabels []string
+	}{
+		{
+			"no matching targets",
+			"required_providers {\n\n}\n",
+			hcl.Pos{Line: 2, Column: 1, Byte: 21},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					ScopeId: lang.ScopeId("variable"),
+				},
+			},
+			[]string{"provider_name"},
+		},
+		{
+			"names from targets",
+			"required_providers {\n\n  google = \"foo\"\n}\n",
+			hcl.Pos{Line: 2, Column: 1, Byte: 21},
+			reference.Targets{
+				providerTarget("google"),
+				providerTarget("aws"),
+				providerTarget("azurerm"),
+				providerTarget("aws"),
+			},
+			[]string{"aws", "azurerm"},
+		},
+		{
+			"names from targets with prefix",
+			"required_providers {\n  az\n}\n",
+			hcl.Pos{Line: 2, Column: 5, Byte: 25},
+			reference.Targets{
+				providerTarget("aws"),
+				providerTarget("azurerm"),
+			},
+			[]string{"azurerm"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: tc.targets,
+			})
+
+			candidates, err := d.CompletionAtPos(context.Background(), "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, c := range candidates.List {
+				labels = append(labels, c.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_defaultSnippet(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"region": {
+				Constraint:     schema.LiteralType{Type: cty.String},
+				IsOptional:     true,
+				DefaultSnippet: `"${1:us-east-1}"`,
+			},
+			"zone": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	pos := hcl.Pos{Line: 1, Column: 1, Byte: 0}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "region",
+			Detail: "optional, string",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				},
+				NewText: "region",
+				Snippet: `region = "${1:us-east-1}"`,
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+		{
+			Label:  "zone",
+			Detail: "optional, string",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				},
+				NewText: "zone",
+				Snippet: `zone = "${1:value}"`,
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+	})
+
+	diff := cmp.Diff(expectedCandidates, candidates, ctydebug.CmpOptions)
+	if diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_attributePriority(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"alpha": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			"name": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+				Priority:   2,
+			},
+			"region": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+				Priority:   1,
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	pos := hcl.Pos{Line: 1, Column: 1, Byte: 0}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := make([]string, 0)
+	sortTexts := make([]string, 0)
+	for _, candidate := range candidates.List {
+		labels = append(labels, candidate.Label)
+		sortTexts = append(sortTexts, candidate.SortText)
+	}
+
+	expectedLabels := []string{"region", "name", "alpha"}
+	if diff := cmp.Diff(expectedLabels, labels); diff != "" {
+		t.Fatalf("unexpected candidate order: %s", diff)
+	}
+
+	expectedSortTexts := []string{
+		"00000000000000000001 region",
+		"00000000000000000002 name",
+		"",
+	}
+	if diff := cmp.Diff(expectedSortTexts, sortTexts); diff != "" {
+		t.Fatalf("unexpected sort texts: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_fuzzyMatching(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"module":       {},
+			"provisioners": {},
+			"resource":     {},
+			"rs_config":    {},
+		},
+	}
+
+	testCases := []struct {
+		testName          string
+		fuzzy             bool
+		expectedLabels    []string
+		expectedSortTexts []string
+	}{
+		{
+			"literal prefix",
+			false,
+			[]string{"rs_config"},
+			[]string{""},
+		},
+		{
+			"fuzzy",
+			true,
+			[]string{"rs_config", "resource", "provisioners"},
+			[]string{
+				"00000 rs_config",
+				"00001 resource",
+				"00004 provisioners",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte("rs\n"), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.EnableFuzzyMatching = tc.fuzzy
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 3, Byte: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			sortTexts := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+				sortTexts = append(sortTexts, candidate.SortText)
+			}
+
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSortTexts, sortTexts); diff != "" {
+				t.Fatalf("unexpected sort texts: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_caseInsensitiveMatching(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"content": {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+			"count":   {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+			"name":    {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+		},
+	}
+
+	testCases := []struct {
+		testName        string
+		cfg             string
+		pos             hcl.Pos
+		caseInsensitive bool
+		expectedLabels  []string
+	}{
+		{
+			"case-sensitive",
+			"Co\n",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			false,
+			[]string{},
+		},
+		{
+			"capitalized prefix",
+			"Co\n",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			true,
+			[]string{"content", "count"},
+		},
+		{
+			"mixed-case prefix",
+			"cOU\n",
+			hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			true,
+			[]string{"count"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.CaseInsensitiveMatching = tc.caseInsensitive
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+				// the canonical name is inserted, replacing the typed prefix
+				if candidate.TextEdit.NewText != candidate.Label {
+					t.Fatalf("expected canonical text %q, got %q", candidate.Label, candidate.TextEdit.NewText)
+				}
+				if candidate.TextEdit.Range.Start.Byte != 0 || candidate.TextEdit.Range.End.Byte != tc.pos.Byte {
+					t.Fatalf("expected edit range to cover the prefix, got %#v", candidate.TextEdit.Range)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_includeDeclaredAttributes(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+						"name":  {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+					},
+				},
+			},
+		},
+	}
+	cfg := `resource {
+  name = "x"
+  n
+}
+`
+	editRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 3, Column: 3, Byte: 26},
+		End:      hcl.Pos{Line: 3, Column: 4, Byte: 27},
+	}
+
+	testCases := []struct {
+		testName           string
+		includeDeclared    bool
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"declared attributes excluded by default",
+			false,
+			hcl.Pos{Line: 3, Column: 4, Byte: 27},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"declared attributes included",
+			true,
+			hcl.Pos{Line: 3, Column: 4, Byte: 27},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "name",
+					Detail: "optional, string",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range:   editRng,
+						NewText: "name",
+						Snippet: `name = "${1:value}"`,
+					},
+					AdditionalTextEdits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 2, Column: 1, Byte: 11},
+								End:      hcl.Pos{Line: 3, Column: 1, Byte: 24},
+							},
+						},
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.IncludeDeclaredAttributes = tc.includeDeclared
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_manualCompletion(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"module":    {},
+			"resource":  {},
+			"rs_config": {},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		manual             bool
+		expectedLabels     []string
+		expectedIsComplete bool
+	}{
+		{
+			"auto without prefix",
+			"\n",
+			hcl.InitialPos,
+			false,
+			[]string{},
+			false,
+		},
+		{
+			"manual without prefix",
+			"\n",
+			hcl.InitialPos,
+			true,
+			[]string{"module", "resource", "rs_config"},
+			true,
+		},
+		{
+			"auto with short prefix",
+			"r\n",
+			hcl.Pos{Line: 1, Column: 2, Byte: 1},
+			false,
+			[]string{},
+			false,
+		},
+		{
+			"manual with short prefix",
+			"r\n",
+			hcl.Pos{Line: 1, Column: 2, Byte: 1},
+			true,
+			[]string{"resource", "rs_config"},
+			true,
+		},
+		{
+			"auto with long enough prefix",
+			"rs\n",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			false,
+			[]string{"rs_config"},
+			true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.MinPrefixForAutoComplete = 2
+
+			reqCtx := ctx
+			if tc.manual {
+				reqCtx = WithManualCompletion(ctx)
+			}
+
+			candidates, err := d.CompletionAtPos(reqCtx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+			}
+
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+			if candidates.IsComplete != tc.expectedIsComplete {
+				t.Fatalf("expected IsComplete: %t, given: %t", tc.expectedIsComplete, candidates.IsComplete)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_manualCompletionPerRequest(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.decoderCtx.Completion.MinPrefixForAutoComplete = 2
+
+	candidates, err := d.CompletionAtPos(WithManualCompletion(ctx), "test.tf", hcl.InitialPos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates.List) != 1 {
+		t.Fatalf("expected manual completion to list candidates, given: %#v", candidates.List)
+	}
+
+	// manual invocation of an earlier request must not
+	// affect a subsequent automatically triggered one
+	candidates, err = d.CompletionAtPos(ctx, "test.tf", hcl.InitialPos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates.List) != 0 || candidates.IsComplete {
+		t.Fatalf("expected auto completion to be prefix-gated, given: %#v", candidates)
+	}
+}
+
+func TestDecoder_CompletionAtPos_multipleTypes(t *testing.T) {
+	ctx := context.Background()
+	resourceLabelSchema := []*schema.LabelSchema{
+		{Name: "type", IsDepKey: true, Completable: true},
+		{Name: "name"},
+	}
+
+	resourceSchema := &schema.BlockSchema{
+		Labels: resourceLabelSchema,
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"for_each": {
 					Constraint: schema.OneOf{
 						schema.LiteralType{Type: cty.Set(cty.DynamicPseudoType)},
 						schema.LiteralType{Type: cty.Map(cty.DynamicPseudoType)},
@@ -1812,3 +2853,328 @@ resource "random_resource" "test" {
   arg = ""
 }
 `)
+
+func TestDecoder_CompletionAtPos_indentNestedSnippets(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"outer": {
+				Body: &schema.BodySchema{
+					Blocks: map[string]*schema.BlockSchema{
+						"inner": {
+							Body: &schema.BodySchema{
+								Attributes: map[string]*schema.AttributeSchema{
+									"obj": {
+										Constraint: schema.Object{
+											Attributes: schema.ObjectAttributes{
+												"foo": {
+													Constraint: schema.LiteralType{Type: cty.String},
+													IsRequired: true,
+												},
+											},
+										},
+										IsOptional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`outer {
+  inner {
+    
+  }
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.PrefillRequiredFields = true
+	d.decoderCtx.Completion.IndentNestedSnippets = true
+
+	pos := hcl.Pos{Line: 3, Column: 5, Byte: 22}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "obj",
+			Detail: "optional, object",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    pos,
+					End:      pos,
+				},
+				NewText: "obj",
+				Snippet: "obj = {\n      foo = \"${1:value}\"\n    }",
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_indentNestedObjectAttributes(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"outer": {
+				Body: &schema.BodySchema{
+					Blocks: map[string]*schema.BlockSchema{
+						"inner": {
+							Body: &schema.BodySchema{
+								Attributes: map[string]*schema.AttributeSchema{
+									"obj": {
+										Constraint: schema.Object{
+											Attributes: schema.ObjectAttributes{
+												"nested": {
+													Constraint: schema.Object{
+														Attributes: schema.ObjectAttributes{
+															"foo": {
+																Constraint: schema.LiteralType{Type: cty.String},
+																IsRequired: true,
+															},
+														},
+													},
+													IsOptional: true,
+												},
+											},
+										},
+										IsOptional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`outer {
+  inner {
+    obj = {
+      
+    }
+  }
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.PrefillRequiredFields = true
+	d.decoderCtx.Completion.IndentNestedSnippets = true
+
+	pos := hcl.Pos{Line: 4, Column: 7, Byte: 36}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "nested",
+			Detail: "optional, object",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    pos,
+					End:      pos,
+				},
+				NewText: "nested",
+				Snippet: "nested = {\n        foo = \"${1:value}\"\n      }",
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_missingRequiredAttributes(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsRequired: true,
+						},
+						"count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+							IsRequired: true,
+						},
+						"enabled": {
+							Constraint: schema.LiteralType{Type: cty.Bool},
+							IsRequired: true,
+						},
+						"name": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsRequired: true,
+						},
+						"tags": {
+							Constraint: schema.LiteralType{Type: cty.Map(cty.String)},
+							IsOptional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`resource {
+  name = "foo"
+  
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.decoderCtx.Completion.SuggestMissingRequiredAttributes = true
+
+	pos := hcl.Pos{Line: 3, Column: 3, Byte: 27}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bulkCandidate *lang.Candidate
+	for i, c := range candidates.List {
+		if c.Label == "required attributes" {
+			bulkCandidate = &candidates.List[i]
+		}
+	}
+	if bulkCandidate == nil {
+		t.Fatalf("expected bulk-insert candidate, got: %#v", candidates.List)
+	}
+
+	expectedCandidate := lang.Candidate{
+		Label:  "required attributes",
+		Detail: "3 missing: ami, count, enabled",
+		Kind:   lang.AttributeCandidateKind,
+		TextEdit: lang.TextEdit{
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    pos,
+				End:      pos,
+			},
+			NewText: "ami = \"value\"\ncount = 0\nenabled = false",
+			Snippet: "ami = \"${1:value}\"\ncount = ${2:0}\nenabled = ${3:false}",
+		},
+	}
+	if diff := cmp.Diff(expectedCandidate, *bulkCandidate); diff != "" {
+		t.Fatalf("unexpected candidate: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_missingRequiredAttributes_single(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsRequired: true,
+						},
+						"name": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsRequired: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`resource {
+  name = "foo"
+  
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.decoderCtx.Completion.SuggestMissingRequiredAttributes = true
+
+	pos := hcl.Pos{Line: 3, Column: 3, Byte: 27}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var bulkCandidate *lang.Candidate
+	for i, c := range candidates.List {
+		if c.Label == "required attributes" {
+			bulkCandidate = &candidates.List[i]
+		}
+	}
+	if bulkCandidate == nil {
+		t.Fatalf("expected bulk-insert candidate, got: %#v", candidates.List)
+	}
+
+	expectedCandidate := lang.Candidate{
+		Label:  "required attributes",
+		Detail: "1 missing: ami",
+		Kind:   lang.AttributeCandidateKind,
+		TextEdit: lang.TextEdit{
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    pos,
+				End:      pos,
+			},
+			NewText: "ami = \"value\"",
+			Snippet: "ami = \"${1:value}\"",
+		},
+	}
+	if diff := cmp.Diff(expectedCandidate, *bulkCandidate); diff != "" {
+		t.Fatalf("unexpected candidate: %s", diff)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 // CodeLensesForFile executes any code lenses in the order declared
@@ -38,3 +39,56 @@ func (d *Decoder) CodeLensesForFile(ctx context.Context, path lang.Path, file st
 
 	return lenses, result.ErrorOrNil()
 }
+
+// BlockCodeLens returns a code lens function, to be registered
+// in DecoderContext.CodeLenses, which calls fn for every block
+// of the given type in the file, including nested blocks.
+//
+// Errors from individual blocks are collected and returned
+// together, without preventing fn from being called for other blocks.
+func BlockCodeLens(blockType string, fn lang.BlockCodeLensFunc) lang.CodeLensFunc {
+	return func(ctx context.Context, path lang.Path, file string) ([]lang.CodeLens, error) {
+		pathCtx, err := PathCtx(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		f, ok := pathCtx.Files[file]
+		if !ok {
+			return nil, &FileNotFoundError{Filename: file}
+		}
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, &UnknownFileFormatError{Filename: file}
+		}
+
+		return blockCodeLensesInBody(ctx, path, body, blockType, fn)
+	}
+}
+
+func blockCodeLensesInBody(ctx context.Context, path lang.Path, body *hclsyntax.Body, blockType string, fn lang.BlockCodeLensFunc) ([]lang.CodeLens, error) {
+	lenses := make([]lang.CodeLens, 0)
+
+	var result *multierror.Error
+
+	for _, block := range body.Blocks {
+		if block.Type == blockType {
+			cls, err := fn(ctx, path, block.AsHCLBlock())
+			if err != nil {
+				result = multierror.Append(result, err)
+			} else {
+				lenses = append(lenses, cls...)
+			}
+		}
+
+		if block.Body != nil {
+			cls, err := blockCodeLensesInBody(ctx, path, block.Body, blockType, fn)
+			if err != nil {
+				result = multierror.Append(result, err)
+			}
+			lenses = append(lenses, cls...)
+		}
+	}
+
+	return lenses, result.ErrorOrNil()
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestBlockCodeLens(t *testing.T) {
+	cfg := []byte(`resource "aws_instance" "foo" {
+  provisioner "local-exec" {
+  }
+}
+variable "bar" {
+}
+`)
+	referencesLens := func(ctx context.Context, path lang.Path, block *hcl.Block) ([]lang.CodeLens, error) {
+		return []lang.CodeLens{
+			{
+				Range: block.DefRange,
+				Command: lang.Command{
+					Title: fmt.Sprintf("%s references", block.Type),
+				},
+			},
+		}, nil
+	}
+
+	testCases := []struct {
+		testName       string
+		codeLenses     []lang.CodeLensFunc
+		expectedLenses []lang.CodeLens
+		expectedErr    string
+	}{
+		{
+			"no providers",
+			nil,
+			[]lang.CodeLens{},
+			"",
+		},
+		{
+			"top-level and nested blocks",
+			[]lang.CodeLensFunc{
+				BlockCodeLens("provisioner", referencesLens),
+				BlockCodeLens("variable", referencesLens),
+			},
+			[]lang.CodeLens{
+				{
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+						End:      hcl.Pos{Line: 2, Column: 27, Byte: 58},
+					},
+					Command: lang.Command{Title: "provisioner references"},
+				},
+				{
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 5, Column: 1, Byte: 67},
+						End:      hcl.Pos{Line: 5, Column: 15, Byte: 81},
+					},
+					Command: lang.Command{Title: "variable references"},
+				},
+			},
+			"",
+		},
+		{
+			"failing provider",
+			[]lang.CodeLensFunc{
+				BlockCodeLens("resource", func(ctx context.Context, path lang.Path, block *hcl.Block) ([]lang.CodeLens, error) {
+					return nil, errors.New("references unavailable")
+				}),
+				BlockCodeLens("variable", referencesLens),
+			},
+			[]lang.CodeLens{
+				{
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 5, Column: 1, Byte: 67},
+						End:      hcl.Pos{Line: 5, Column: 15, Byte: 81},
+					},
+					Command: lang.Command{Title: "variable references"},
+				},
+			},
+			"references unavailable",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+			dirPath := t.TempDir()
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+					},
+				},
+			})
+			d.SetContext(DecoderContext{
+				CodeLenses: tc.codeLenses,
+			})
+
+			lenses, err := d.CodeLensesForFile(context.Background(), lang.Path{Path: dirPath}, "test.tf")
+			if tc.expectedErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tc.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected error: %q", tc.expectedErr)
+				}
+				if !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.expectedLenses, lenses); diff != "" {
+				t.Fatalf("unexpected lenses: %s", diff)
+			}
+		})
+	}
+}
@@ -5,8 +5,18 @@ package decoder
 
 import (
 	"context"
+
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+// descriptionResolveHookName identifies the built-in resolve hook
+// of candidates whose description is provided via DescriptionFunc
+const descriptionResolveHookName = "hcl-lang.description"
+
 // ResolveCandidate gathers more information for a completion candidate
 // by checking for a resolve hook and executing it.
 // This would be called as part of `completionItem/resolve` LSP method.
@@ -15,9 +25,123 @@ func (d *Decoder) ResolveCandidate(ctx context.Context, unresolvedCandidate Unre
 		return nil, nil
 	}
 
+	if unresolvedCandidate.ResolveHook.Name == descriptionResolveHookName {
+		return d.resolveDescription(unresolvedCandidate.ResolveHook)
+	}
+
 	if resolveFunc, ok := d.ctx.CompletionResolveHooks[unresolvedCandidate.ResolveHook.Name]; ok {
 		return resolveFunc(ctx, unresolvedCandidate)
 	}
 
 	return nil, nil
 }
+
+type descriptionResolveHookCtxKey struct{}
+
+// withDescriptionResolveHook sets the hook which locates candidates
+// of the completion request, for their descriptions to be resolved
+// lazily via DescriptionFunc
+func withDescriptionResolveHook(ctx context.Context, hook lang.ResolveHook) context.Context {
+	return context.WithValue(ctx, descriptionResolveHookCtxKey{}, hook)
+}
+
+// candidateDescription returns the given description, or a resolve hook
+// deferring the call of fn until the candidate is resolved, if fn is set.
+// fn is called right away if deferring isn't possible.
+func candidateDescription(ctx context.Context, field string, description lang.MarkupContent, fn schema.DescriptionFunc) (lang.MarkupContent, *lang.ResolveHook) {
+	if fn == nil {
+		return description, nil
+	}
+	hook, ok := ctx.Value(descriptionResolveHookCtxKey{}).(lang.ResolveHook)
+	if !ok {
+		return fn(), nil
+	}
+	hook.Field = field
+	return lang.MarkupContent{}, &hook
+}
+
+// resolveDescription looks up the schema of the attribute or block
+// at the position recorded in the hook and calls its DescriptionFunc
+func (d *Decoder) resolveDescription(hook *lang.ResolveHook) (*ResolvedCandidate, error) {
+	if hook.Pos == nil {
+		return nil, nil
+	}
+
+	pd, err := d.Path(lang.Path{
+		Path:       hook.Path,
+		LanguageID: hook.LanguageID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bodySchema, err := pd.bodySchemaAtPos(hook.Filename, *hook.Pos)
+	if err != nil {
+		return nil, err
+	}
+
+	fn := descriptionFuncForField(bodySchema, hook.Field)
+	if fn == nil {
+		return nil, nil
+	}
+
+	return &ResolvedCandidate{
+		Description: fn(),
+	}, nil
+}
+
+// descriptionFuncForField returns DescriptionFunc of the attribute
+// or block of the given name, preferring attributes as completion does
+func descriptionFuncForField(bodySchema *schema.BodySchema, field string) schema.DescriptionFunc {
+	if bodySchema == nil {
+		return nil
+	}
+	if attr, ok := bodySchema.Attributes[field]; ok {
+		return attr.DescriptionFunc
+	}
+	if block, ok := bodySchema.Blocks[field]; ok {
+		return block.DescriptionFunc
+	}
+	if len(bodySchema.Attributes) == 0 && bodySchema.AnyAttribute != nil {
+		return bodySchema.AnyAttribute.DescriptionFunc
+	}
+	return nil
+}
+
+// bodySchemaAtPos returns the (merged) schema of the innermost body
+// enclosing the given position
+func (d *PathDecoder) bodySchemaAtPos(filename string, pos hcl.Pos) (*schema.BodySchema, error) {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	rootBody, err := d.bodyForFileAndPos(filename, f, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pathCtx.Schema == nil {
+		return nil, &NoSchemaError{}
+	}
+
+	return bodySchemaInBody(rootBody, d.pathCtx.Schema, pos), nil
+}
+
+func bodySchemaInBody(body *hclsyntax.Body, bodySchema *schema.BodySchema, pos hcl.Pos) *schema.BodySchema {
+	for _, block := range body.Blocks {
+		if block.Body == nil || !block.Body.Range().ContainsPos(pos) {
+			continue
+		}
+
+		blockSchema, ok := bodySchema.Blocks[block.Type]
+		if !ok {
+			return nil
+		}
+
+		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+		return bodySchemaInBody(block.Body, mergedSchema, pos)
+	}
+
+	return bodySchema
+}
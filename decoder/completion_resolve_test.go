@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestResolveCandidate_lazyDescription(t *testing.T) {
+	attrCalls, blockCalls := 0, 0
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.LiteralType{Type: cty.Number},
+				IsOptional: true,
+				DescriptionFunc: func() lang.MarkupContent {
+					attrCalls++
+					return lang.PlainText("Lazy attribute")
+				},
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"block": {
+				DescriptionFunc: func() lang.MarkupContent {
+					blockCalls++
+					return lang.Markdown("Lazy block")
+				},
+			},
+		},
+	}
+
+	otherSchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				DescriptionFunc: func() lang.MarkupContent {
+					return lang.PlainText("Other attribute")
+				},
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+	dirPath, otherDirPath := t.TempDir(), t.TempDir()
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+			otherDirPath: {
+				Schema: otherSchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	})
+	d.SetContext(NewDecoderContext())
+
+	ctx := context.Background()
+	completionAtPos := func(path string) lang.Candidates {
+		pd, err := d.Path(lang.Path{Path: path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		candidates, err := pd.CompletionAtPos(ctx, "test.tf", hcl.InitialPos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return candidates
+	}
+
+	candidates := completionAtPos(dirPath)
+	if attrCalls != 0 || blockCalls != 0 {
+		t.Fatalf("expected no description calls during completion, got %d (attribute), %d (block)",
+			attrCalls, blockCalls)
+	}
+
+	// candidates must remain resolvable after
+	// any later completion in another path
+	completionAtPos(otherDirPath)
+
+	descriptions := make(map[string]lang.MarkupContent, 0)
+	for _, candidate := range candidates.List {
+		if candidate.Description.Value != "" {
+			t.Fatalf("expected description of %q to be deferred, given: %#v", candidate.Label, candidate.Description)
+		}
+		if candidate.ResolveHook == nil || candidate.ResolveHook.Path != dirPath {
+			t.Fatalf("expected resolve hook of %q to point to %q, given: %#v", candidate.Label, dirPath, candidate.ResolveHook)
+		}
+		resolved, err := d.ResolveCandidate(ctx, UnresolvedCandidate{
+			ResolveHook: candidate.ResolveHook,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved == nil {
+			t.Fatalf("expected %q to be resolved", candidate.Label)
+		}
+		descriptions[candidate.Label] = resolved.Description
+	}
+
+	expectedDescriptions := map[string]lang.MarkupContent{
+		"attr":  lang.PlainText("Lazy attribute"),
+		"block": lang.Markdown("Lazy block"),
+	}
+	if diff := cmp.Diff(expectedDescriptions, descriptions); diff != "" {
+		t.Fatalf("unexpected resolved descriptions: %s", diff)
+	}
+	if attrCalls != 1 || blockCalls != 1 {
+		t.Fatalf("expected a single description call per resolved candidate, got %d (attribute), %d (block)",
+			attrCalls, blockCalls)
+	}
+}
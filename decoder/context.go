@@ -39,6 +39,110 @@ type DecoderContext struct {
 	// a resolve hook, ResolveCandidate will execute the hook and return
 	// additional (resolved) data for the completion item.
 	CompletionResolveHooks CompletionResolveFuncMap
+
+	// EmptyFileScaffold represents an optional template offered
+	// as an additional completion candidate when completing
+	// inside of an empty file (or a file only containing whitespace).
+	EmptyFileScaffold *Scaffold
+
+	// Completion represents options affecting completion
+	// candidates returned from CompletionAtPos
+	Completion CompletionOptions
+}
+
+// CompletionOptions represents options affecting completion
+// candidates, the zero value of which keeps the default behaviour
+type CompletionOptions struct {
+	// MaxCandidates overrides the maximum number of completion
+	// candidates returned. Candidates beyond the limit are left out
+	// and the returned list is marked as incomplete (IsComplete: false),
+	// so that clients know to re-query as the user types.
+	MaxCandidates uint
+
+	// IndentNestedSnippets indents continuation lines of multi-line
+	// attribute snippets to match the nesting level of the enclosing
+	// block, for clients which do not adjust the indentation themselves
+	IndentNestedSnippets bool
+
+	// QualifyLabelCandidates qualifies label completion candidates
+	// with the namespace of the dependent body (if any), such that
+	// candidates are grouped by namespace. The namespace is shown
+	// in the detail, while the label and inserted text remain
+	// unqualified, so that candidates still match the typed prefix.
+	QualifyLabelCandidates bool
+
+	// SuggestMissingRequiredAttributes adds a completion candidate
+	// inside block bodies which inserts all required attributes
+	// not yet declared in the body, in a single edit
+	SuggestMissingRequiredAttributes bool
+
+	// SuggestInterpolatedReferences adds a variant of each reference
+	// candidate wrapped in a string template (e.g. "${var.foo}")
+	// for attributes expecting a reference, alongside the bare reference
+	SuggestInterpolatedReferences bool
+
+	// EnableFuzzyMatching matches attribute names, block types
+	// and labels in completion if the typed prefix is a subsequence
+	// of the name, rather than a literal prefix, and ranks
+	// candidates by how closely they match
+	EnableFuzzyMatching bool
+
+	// CaseInsensitiveMatching ignores case when matching the typed
+	// prefix against attribute names, block types and labels
+	// in completion (e.g. Co matches count). The inserted text
+	// keeps the canonical case from the schema.
+	CaseInsensitiveMatching bool
+
+	// ExpandNestedBlockLabels adds a completion candidate for nested
+	// blocks per each combination of dependency-key label values
+	// known from the dependent body schemas, with the labels prefilled
+	// (e.g. ingress "tcp" { }), alongside the plain block candidate
+	ExpandNestedBlockLabels bool
+
+	// IncludeDeclaredAttributes keeps offering attributes already
+	// declared in the body as completion candidates, so that clients
+	// can re-trigger the value snippet. Such a candidate is inserted
+	// at the cursor and removes the line(s) of the existing attribute
+	// (name = value) via an additional edit.
+	IncludeDeclaredAttributes bool
+
+	// MinPrefixForAutoComplete is the minimum number of characters
+	// which must be typed before automatically triggered completion
+	// lists attribute names, block types and labels. Until then
+	// an empty incomplete list is returned, so that clients
+	// re-query as the user types.
+	// Completion invoked manually (see WithManualCompletion)
+	// always lists all matching candidates.
+	MinPrefixForAutoComplete int
+
+	// SignatureHelpCommandID identifies a client command which
+	// requests signature help (parameter hints), such as
+	// "editor.action.triggerParameterHints" in VS Code. If set,
+	// candidates of functions with parameters carry the command,
+	// so that signature help is shown once a function is inserted.
+	SignatureHelpCommandID string
+}
+
+// Scaffold represents a minimal starting document
+// which can be inserted into an empty file via completion
+type Scaffold struct {
+	// Label represents a human-readable name of the scaffold
+	Label string
+
+	// Detail represents a human-readable string with additional
+	// information about the scaffold
+	Detail string
+
+	// Description represents human-readable description
+	// of the scaffold
+	Description lang.MarkupContent
+
+	// NewText represents the text to insert
+	NewText string
+
+	// Snippet represents the snippet equivalent of NewText
+	// which may contain placeholders, such as ${1}.
+	Snippet string
 }
 
 func NewDecoderContext() DecoderContext {
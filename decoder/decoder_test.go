@@ -5,10 +5,15 @@ package decoder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type testPathReader struct {
@@ -53,3 +58,90 @@ func testPathDecoder(t *testing.T, pathCtx *PathContext) *PathDecoder {
 
 	return pathDecoder
 }
+
+func TestDecoder_cancelledContext(t *testing.T) {
+	resourceSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "type", IsDepKey: true, Completable: true},
+		},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			schema.NewSchemaKey(schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "aws_instance"},
+				},
+			}): {},
+		},
+	}
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {Constraint: schema.LiteralType{Type: cty.String}},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Body: &schema.BodySchema{},
+			},
+			"resource": resourceSchema,
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte(`attr = "foo"
+myblock {
+}
+resource "" {
+}
+`), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	testCases := []struct {
+		name string
+		fn   func(ctx context.Context) error
+	}{
+		{
+			"completion",
+			func(ctx context.Context) error {
+				_, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 3, Column: 1, Byte: 23})
+				return err
+			},
+		},
+		{
+			"label completion from dependent bodies",
+			func(ctx context.Context) error {
+				block := f.Body.(*hclsyntax.Body).Blocks[1]
+				rng := block.LabelRanges[0]
+				_, err := d.labelCandidatesFromDependentSchema(ctx, 0, resourceSchema.DependentBody,
+					rng, rng, block, resourceSchema.Labels)
+				return err
+			},
+		},
+		{
+			"hover",
+			func(ctx context.Context) error {
+				_, err := d.HoverAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 2, Byte: 1})
+				return err
+			},
+		},
+		{
+			"semantic tokens",
+			func(ctx context.Context) error {
+				_, err := d.SemanticTokensInFile(ctx, "test.tf")
+				return err
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := tc.fn(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, given: %#v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// dependsOnCompletionAtPos returns candidates for an element
+// of the depends_on meta-argument, i.e. addresses of top-level
+// blocks other than the one in which depends_on is declared.
+func (d *PathDecoder) dependsOnCompletionAtPos(ctx context.Context, attr *hclsyntax.Attribute, scopeIds []lang.ScopeId, outerBodyRng hcl.Range, pos hcl.Pos) (lang.Candidates, error) {
+	eType, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return d.attrValueCompletionAtPos(ctx, attr, schemahelper.DependsOnAttributeSchema(scopeIds), outerBodyRng, pos)
+	}
+
+	filename := eType.Range().Filename
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return lang.ZeroCandidates(), err
+	}
+
+	betweenBrackets := hcl.Range{
+		Filename: filename,
+		Start:    eType.OpenRange.End,
+		End:      eType.Range().End,
+	}
+	if !betweenBrackets.ContainsPos(pos) {
+		return lang.ZeroCandidates(), nil
+	}
+
+	editRng := hcl.Range{
+		Filename: filename,
+		Start:    pos,
+		End:      pos,
+	}
+	prefix := ""
+	declared := make(map[string]bool, 0)
+	for _, elemExpr := range eType.Exprs {
+		if isEmptyExpression(elemExpr) {
+			continue
+		}
+		rng := elemExpr.Range()
+		if rng.ContainsPos(pos) || rng.End.Byte == pos.Byte ||
+			(pos.Byte-rng.End.Byte == 1 && f.Bytes[rng.End.Byte] == '.') {
+			editRng = hcl.Range{
+				Filename: filename,
+				Start:    rng.Start,
+				End:      pos,
+			}
+			if rng.End.Byte > pos.Byte {
+				editRng.End = rng.End
+			}
+			prefix = string(f.Bytes[rng.Start.Byte:pos.Byte])
+			continue
+		}
+		declared[strings.TrimSpace(string(rng.SliceBytes(f.Bytes)))] = true
+	}
+
+	topLevelBlocks := d.topLevelBlockDefRanges()
+
+	candidates := lang.NewCandidates()
+	seen := make(map[string]bool, 0)
+	for _, target := range d.pathCtx.ReferenceTargets {
+		if len(target.Addr) == 0 || target.RangePtr == nil || target.DefRangePtr == nil {
+			continue
+		}
+		if !topLevelBlocks[*target.DefRangePtr] || !target.MatchesAnyScopeId(scopeIds) {
+			continue
+		}
+		// a block cannot depend on itself
+		if target.RangePtr.Filename == filename && target.RangePtr.ContainsPos(pos) {
+			continue
+		}
+
+		address := target.Addr.String()
+		if seen[address] || declared[address] {
+			continue
+		}
+		penalty, ok := d.matchPrefix(address, prefix)
+		if !ok {
+			continue
+		}
+		seen[address] = true
+
+		candidates.List = append(candidates.List, d.withMatchSortText(lang.Candidate{
+			Label:       address,
+			Detail:      target.FriendlyName(),
+			Description: target.Description,
+			Kind:        lang.ReferenceCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: address,
+				Snippet: address,
+				Range:   editRng,
+			},
+		}, penalty, prefix))
+	}
+
+	sort.Sort(candidates)
+
+	return d.truncateCandidates(candidates), nil
+}
+
+// topLevelBlockDefRanges returns definition ranges
+// of all blocks declared in root bodies of the path's files
+func (d *PathDecoder) topLevelBlockDefRanges() map[hcl.Range]bool {
+	ranges := make(map[hcl.Range]bool, 0)
+	for _, f := range d.pathCtx.Files {
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			ranges[block.DefRange()] = true
+		}
+	}
+	return ranges
+}
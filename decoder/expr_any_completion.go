@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func (a Any) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
@@ -23,7 +24,8 @@ func (a Any) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 
 		cons := schema.List{
 			Elem: schema.AnyExpression{
-				OfType: typ.ElementType(),
+				OfType:           typ.ElementType(),
+				PreferReferences: a.cons.PreferReferences,
 			},
 		}
 
@@ -38,7 +40,8 @@ func (a Any) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 
 		cons := schema.Set{
 			Elem: schema.AnyExpression{
-				OfType: typ.ElementType(),
+				OfType:           typ.ElementType(),
+				PreferReferences: a.cons.PreferReferences,
 			},
 		}
 
@@ -72,7 +75,8 @@ func (a Any) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 
 		cons := schema.Map{
 			Elem: schema.AnyExpression{
-				OfType: typ.ElementType(),
+				OfType:           typ.ElementType(),
+				PreferReferences: a.cons.PreferReferences,
 			},
 			AllowInterpolatedKeys: true,
 		}
@@ -131,26 +135,55 @@ func (a Any) completeNonComplexExprAtPos(ctx context.Context, pos hcl.Pos) []lan
 		cons:    schema.Reference{OfType: a.cons.OfType},
 		pathCtx: a.pathCtx,
 	}
-	candidates = append(candidates, ref.CompletionAtPos(ctx, pos)...)
+	refCandidates := ref.CompletionAtPos(ctx, pos)
+	if a.cons.PreferReferences {
+		// rank references ahead of everything else
+		candidates = withSortTextPrefix(candidates, "1")
+		refCandidates = withSortTextPrefix(refCandidates, "0")
+		candidates = append(refCandidates, candidates...)
+	} else {
+		candidates = append(candidates, refCandidates...)
+	}
 
 	fe := functionExpr{
 		expr:       a.expr,
 		returnType: a.cons.OfType,
 		pathCtx:    a.pathCtx,
 	}
-	candidates = append(candidates, fe.CompletionAtPos(ctx, pos)...)
-
-	lt := LiteralType{
-		expr: a.expr,
-		cons: schema.LiteralType{
-			Type:             a.cons.OfType,
-			SkipComplexTypes: a.cons.SkipLiteralComplexTypes,
-		},
-		pathCtx: a.pathCtx,
+	otherCandidates := fe.CompletionAtPos(ctx, pos)
+
+	// type starters for dynamic values would only crowd out
+	// references and functions, which are offered already
+	if a.cons.OfType != cty.DynamicPseudoType {
+		lt := LiteralType{
+			expr: a.expr,
+			cons: schema.LiteralType{
+				Type:             a.cons.OfType,
+				SkipComplexTypes: a.cons.SkipLiteralComplexTypes,
+			},
+			pathCtx: a.pathCtx,
+		}
+		otherCandidates = append(otherCandidates, lt.CompletionAtPos(ctx, pos)...)
+	}
+
+	otherCandidates = append(otherCandidates, a.completeIndexExprAtPos(ctx, pos)...)
+
+	if a.cons.PreferReferences {
+		otherCandidates = withSortTextPrefix(otherCandidates, "1")
 	}
-	candidates = append(candidates, lt.CompletionAtPos(ctx, pos)...)
 
-	candidates = append(candidates, a.completeIndexExprAtPos(ctx, pos)...)
+	return append(candidates, otherCandidates...)
+}
 
+// withSortTextPrefix prefixes sort text of the given candidates,
+// falling back to the label where no sort text is set
+func withSortTextPrefix(candidates []lang.Candidate, prefix string) []lang.Candidate {
+	for i, candidate := range candidates {
+		sortKey := candidate.SortText
+		if sortKey == "" {
+			sortKey = candidate.Label
+		}
+		candidates[i].SortText = prefix + " " + sortKey
+	}
 	return candidates
 }
@@ -48,7 +48,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -63,7 +63,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -78,7 +78,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -93,7 +93,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -108,7 +108,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -138,7 +138,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -146,6 +146,20 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -169,7 +183,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -184,7 +198,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -199,7 +213,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -214,7 +228,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -229,7 +243,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -259,7 +273,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -274,12 +288,26 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "split()",
-						Snippet: "split(${0})",
+						Snippet: "split(${1:separator}, ${2:str})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
 						},
+						NewText: "null",
+						Snippet: "null",
 					},
 				},
 			}),
@@ -305,7 +333,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -363,7 +391,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -378,7 +406,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -393,7 +421,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -408,7 +436,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -423,7 +451,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -453,7 +481,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -468,7 +496,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "split()",
-						Snippet: "split(${0})",
+						Snippet: "split(${1:separator}, ${2:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 15},
@@ -533,6 +561,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 						NewText: "var.lst",
 						Snippet: "var.lst",
 					},
+					TriggerSuggestChars: []string{"["},
 				},
 			}),
 		},
@@ -591,6 +620,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 						NewText: "var.obj",
 						Snippet: "var.obj",
 					},
+					TriggerSuggestChars: []string{"."},
 				},
 			}),
 		},
@@ -663,6 +693,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 						NewText: `var.map`,
 						Snippet: `var.map`,
 					},
+					TriggerSuggestChars: []string{"["},
 				},
 				{
 					Label:       "element",
@@ -671,7 +702,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
@@ -686,7 +717,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
@@ -701,7 +732,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
@@ -716,7 +747,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
@@ -731,7 +762,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
@@ -761,7 +792,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
@@ -837,7 +868,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 29},
@@ -852,7 +883,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 29},
@@ -867,7 +898,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 29},
@@ -882,7 +913,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 29},
@@ -897,7 +928,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 29},
@@ -912,7 +943,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 29},
@@ -943,7 +974,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 23},
@@ -958,7 +989,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 23},
@@ -973,7 +1004,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 23},
@@ -988,7 +1019,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 23},
@@ -1003,7 +1034,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 23},
@@ -1033,7 +1064,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 23},
@@ -1043,6 +1074,81 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"nested function argument with inner parameter type",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Targets{},
+			`attr = lower(join(",", ))
+`,
+			hcl.Pos{Line: 1, Column: 24, Byte: 23},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       "element",
+					Detail:      "element(list dynamic, index number) dynamic",
+					Description: lang.Markdown("`element` retrieves a single element from a list."),
+					Kind:        lang.FunctionCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "element()",
+						Snippet: "element(${1:list}, ${2:index})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 24, Byte: 23},
+							End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+						},
+					},
+				},
+				{
+					Label:       "keys",
+					Detail:      "keys(inputMap dynamic) dynamic",
+					Description: lang.Markdown("`keys` takes a map and returns a list containing the keys from that map."),
+					Kind:        lang.FunctionCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "keys()",
+						Snippet: "keys(${1:inputMap})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 24, Byte: 23},
+							End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+						},
+					},
+				},
+				{
+					Label:       "split",
+					Detail:      "split(separator string, str string) list of string",
+					Description: lang.Markdown("`split` produces a list by dividing a given string at all occurrences of a given separator."),
+					Kind:        lang.FunctionCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "split()",
+						Snippet: "split(${1:separator}, ${2:str})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 24, Byte: 23},
+							End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+						},
+					},
+				},
+				{
+					Label:  "[ string ]",
+					Detail: "list of string",
+					Kind:   lang.ListCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `[ "" ]`,
+						Snippet: `[ "${1:value}" ]`,
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 24, Byte: 23},
+							End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+						},
+					},
+				},
+			}),
+		},
 		{
 			"in front of prefix (with space)",
 			map[string]*schema.AttributeSchema{
@@ -1093,7 +1199,7 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1225,7 +1331,7 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1240,7 +1346,7 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1255,7 +1361,7 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1270,7 +1376,7 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1300,7 +1406,7 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1336,6 +1442,20 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -1425,7 +1545,7 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "tobool()",
-						Snippet: "tobool(${0})",
+						Snippet: "tobool(${1:v})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -1479,6 +1599,84 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 	}
 }
 
+func TestCompletionAtPos_exprAny_signatureHelpCommand(t *testing.T) {
+	attrSchema := map[string]*schema.AttributeSchema{
+		"attr": {
+			Constraint: schema.AnyExpression{
+				OfType: cty.String,
+			},
+		},
+	}
+	functions := map[string]schema.FunctionSignature{
+		"lower": {
+			Params: []function.Parameter{
+				{
+					Name: "str",
+					Type: cty.String,
+				},
+			},
+			ReturnType: cty.String,
+		},
+		"uuid": {
+			ReturnType: cty.String,
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("attr = \n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: &schema.BodySchema{
+			Attributes: attrSchema,
+		},
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		Functions: functions,
+	})
+	d.decoderCtx.Completion.SignatureHelpCommandID = "test.triggerSignatureHelp"
+
+	candidates, err := d.CompletionAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commands := make(map[string]*lang.Command, 0)
+	for _, candidate := range candidates.List {
+		if candidate.Kind == lang.FunctionCandidateKind {
+			commands[candidate.Label] = candidate.Command
+		}
+	}
+	expectedCommands := map[string]*lang.Command{
+		"lower": {
+			Title: "Trigger Signature Help",
+			ID:    "test.triggerSignatureHelp",
+		},
+		"uuid": nil,
+	}
+	if diff := cmp.Diff(expectedCommands, commands); diff != "" {
+		t.Fatalf("unexpected commands: %s", diff)
+	}
+}
+
+func TestParameterSnippet_escaping(t *testing.T) {
+	signature := schema.FunctionSignature{
+		Params: []function.Parameter{
+			{
+				Name: "${prefix}",
+				Type: cty.String,
+			},
+		},
+		VarParam: &function.Parameter{
+			Name: `path\parts`,
+			Type: cty.String,
+		},
+	}
+
+	expectedSnippet := `${1:\${prefix\}}, ${2:path\\parts}`
+	if diff := cmp.Diff(expectedSnippet, parameterSnippet(signature)); diff != "" {
+		t.Fatalf("unexpected snippet: %s", diff)
+	}
+}
+
 func testFunctionSignatures() map[string]schema.FunctionSignature {
 	return map[string]schema.FunctionSignature{
 		"element": {
@@ -1629,6 +1827,20 @@ func TestCompletionAtPos_exprAny_literalTypes(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -1672,7 +1884,22 @@ func TestCompletionAtPos_exprAny_literalTypes(t *testing.T) {
 			`attr = 
 `,
 			hcl.Pos{Line: 1, Column: 8, Byte: 7},
-			lang.CompleteCandidates([]lang.Candidate{}),
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
 		},
 		{
 			"list of strings",
@@ -1701,6 +1928,20 @@ func TestCompletionAtPos_exprAny_literalTypes(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -1872,6 +2113,20 @@ func TestCompletionAtPos_exprAny_literalTypes(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -2029,6 +2284,20 @@ func TestCompletionAtPos_exprAny_literalTypes(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -2265,6 +2534,20 @@ func TestCompletionAtPos_exprAny_literalTypes(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -2841,6 +3124,20 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -3061,6 +3358,96 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 	}
 }
 
+func TestCompletionAtPos_exprAny_preferReferences(t *testing.T) {
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "enabled"},
+			},
+			Type: cty.Bool,
+		},
+	}
+
+	testCases := []struct {
+		testName          string
+		constraint        schema.Constraint
+		cfg               string
+		pos               hcl.Pos
+		expectedLabels    []string
+		expectedSortTexts []string
+	}{
+		{
+			"without hint",
+			schema.AnyExpression{
+				OfType: cty.Bool,
+			},
+			"attr = \n",
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			[]string{"var.enabled", "false", "true", "null"},
+			[]string{"", "", "", ""},
+		},
+		{
+			"with hint",
+			schema.AnyExpression{
+				OfType:           cty.Bool,
+				PreferReferences: true,
+			},
+			"attr = \n",
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			[]string{"var.enabled", "false", "true", "null"},
+			[]string{"0 var.enabled", "1 false", "1 true", ""},
+		},
+		{
+			"list element with hint",
+			schema.AnyExpression{
+				OfType:           cty.List(cty.Bool),
+				PreferReferences: true,
+			},
+			"attr = [  ]\n",
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			[]string{"var.enabled", "false", "true"},
+			[]string{"0 var.enabled", "1 false", "1 true"},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"attr": {Constraint: tc.constraint},
+				},
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: refTargets,
+			})
+
+			candidates, err := d.CompletionAtPos(context.Background(), "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			sortTexts := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+				sortTexts = append(sortTexts, candidate.SortText)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedSortTexts, sortTexts); diff != "" {
+				t.Fatalf("unexpected sort texts: %s", diff)
+			}
+		})
+	}
+}
+
 func TestCompletionAtPos_exprAny_skipComplex(t *testing.T) {
 	testCases := []struct {
 		testName           string
@@ -3174,7 +3561,7 @@ func TestCompletionAtPos_exprAny_skipComplex(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 2, Column: 12, Byte: 20},
@@ -3404,7 +3791,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
@@ -3419,7 +3806,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
@@ -3434,7 +3821,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
@@ -3449,7 +3836,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
@@ -3479,7 +3866,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
@@ -3716,7 +4103,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
@@ -3731,7 +4118,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
@@ -3746,7 +4133,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
@@ -3761,7 +4148,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
@@ -3776,7 +4163,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
@@ -3806,7 +4193,7 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
@@ -3928,6 +4315,20 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+							End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -3973,6 +4374,20 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+							End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
 			}),
 		},
 		{
@@ -5215,7 +5630,7 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
@@ -5230,7 +5645,7 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
@@ -5245,7 +5660,7 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
@@ -5260,7 +5675,7 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
@@ -5275,7 +5690,7 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
@@ -5305,7 +5720,7 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
@@ -5358,6 +5773,94 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"template with prefix surrounded by literal text",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.String,
+				},
+			},
+			`attr = "foo-${v}-bar"
+`,
+			hcl.Pos{Line: 1, Column: 16, Byte: 15},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.bar",
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.bar",
+						Snippet: "var.bar",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
+							End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"indented heredoc template with prefix",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.String,
+				},
+			},
+			`attr = <<-EOT
+    foo ${v}
+  EOT
+`,
+			hcl.Pos{Line: 2, Column: 12, Byte: 25},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.bar",
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.bar",
+						Snippet: "var.bar",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 11, Byte: 24},
+							End:      hcl.Pos{Line: 2, Column: 12, Byte: 25},
+						},
+					},
+				},
+			}),
+		},
 		{
 			"simple empty template with prefix trailing dot (wrapped)",
 			map[string]*schema.AttributeSchema{
@@ -5452,7 +5955,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 10, Byte: 38},
@@ -5467,7 +5970,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 10, Byte: 38},
@@ -5482,7 +5985,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 10, Byte: 38},
@@ -5497,7 +6000,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 10, Byte: 38},
@@ -5512,7 +6015,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 10, Byte: 38},
@@ -5542,7 +6045,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 10, Byte: 38},
@@ -5586,7 +6089,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 30, Byte: 29},
@@ -5601,7 +6104,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 30, Byte: 29},
@@ -5689,7 +6192,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
@@ -5704,7 +6207,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
@@ -5719,7 +6222,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
@@ -5734,7 +6237,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
@@ -5749,7 +6252,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
@@ -5764,7 +6267,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 28, Byte: 27},
@@ -5908,7 +6411,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -5923,7 +6426,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -5938,7 +6441,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -5953,7 +6456,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -5968,7 +6471,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -5998,7 +6501,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6037,7 +6540,7 @@ EOT
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  "var.bar",
-					Detail: "string",
+					Detail: "string, set element",
 					Kind:   lang.ReferenceCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
@@ -6056,7 +6559,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6071,7 +6574,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6086,7 +6589,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6101,7 +6604,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6116,7 +6619,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6146,7 +6649,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6207,7 +6710,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "element()",
-						Snippet: "element(${0})",
+						Snippet: "element(${1:list}, ${2:index})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6222,7 +6725,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "join()",
-						Snippet: "join(${0})",
+						Snippet: "join(${1:separator}, ${2:lists})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6237,7 +6740,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "keys()",
-						Snippet: "keys(${0})",
+						Snippet: "keys(${1:inputMap})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6252,7 +6755,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "log()",
-						Snippet: "log(${0})",
+						Snippet: "log(${1:num}, ${2:base})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6267,7 +6770,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "lower()",
-						Snippet: "lower(${0})",
+						Snippet: "lower(${1:str})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6297,7 +6800,7 @@ EOT
 					Kind:        lang.FunctionCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "provider::framework::example()",
-						Snippet: "provider::framework::example(${0})",
+						Snippet: "provider::framework::example(${1:input})",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
@@ -6690,3 +7193,139 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprAny_conditionalCollection(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		attrSchema         map[string]*schema.AttributeSchema
+		refTargets         reference.Targets
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"list literal in true branch",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.Bool),
+					},
+				},
+			},
+			reference.Targets{},
+			`attr = true ? [  ] : []`,
+			hcl.Pos{Line: 1, Column: 17, Byte: 16},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "false",
+						Snippet: "false",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						},
+					},
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "true",
+						Snippet: "true",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"list literal in false branch",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.String),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.String,
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.List(cty.String),
+				},
+			},
+			`attr = true ? [] : [ va ]`,
+			hcl.Pos{Line: 1, Column: 24, Byte: 23},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.foo",
+					Detail: "string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
+							End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+						},
+						NewText: "var.foo",
+						Snippet: "var.foo",
+					},
+					Kind: lang.ReferenceCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: tc.attrSchema,
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: tc.refTargets,
+			})
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
@@ -2545,6 +2545,61 @@ EOT
 				},
 			},
 		},
+		{
+			"indented heredoc with reference",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "local"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 24},
+						End:      hcl.Pos{Line: 2, Column: 20, Byte: 33},
+					},
+					Constraints: reference.OriginConstraints{
+						{
+							OfType: cty.String,
+						},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "local"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.String,
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 5, Column: 1, Byte: 34},
+						End:      hcl.Pos{Line: 5, Column: 13, Byte: 45},
+					},
+				},
+			},
+			`attr = <<-EOT
+    foo ${local.foo}
+  EOT
+`,
+			hcl.Pos{Line: 2, Column: 13, Byte: 26},
+			&lang.HoverData{
+				Content: lang.Markdown("`local.foo`\n_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 11, Byte: 24},
+					End:      hcl.Pos{Line: 2, Column: 20, Byte: 33},
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -14,7 +14,7 @@ import (
 )
 
 func (a Any) completeIndexExprAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
-	var candidates []lang.Candidate
+	candidates := make([]lang.Candidate, 0)
 
 	cons := schema.AnyExpression{
 		// TODO we could improve this by looking up the type of the
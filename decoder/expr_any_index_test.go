@@ -124,7 +124,8 @@ func TestCompletionAtPos_exprAny_index(t *testing.T) {
 						NewText: `aws_instance.name`,
 						Snippet: `aws_instance.name`,
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:                lang.ReferenceCandidateKind,
+					TriggerSuggestChars: []string{"."},
 				},
 				{
 					Label:  `local.name`,
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -19,6 +20,8 @@ func (a Any) ReferenceTargets(ctx context.Context, targetCtx *TargetContext) ref
 		val, diags := a.expr.Value(&hcl.EvalContext{})
 		if !diags.HasErrors() {
 			typ = val.Type()
+		} else if tupleType, ok := tupleTypeOfTupleConsExpr(a.expr); ok {
+			typ = tupleType
 		}
 	}
 
@@ -79,6 +82,13 @@ func (a Any) ReferenceTargets(ctx context.Context, targetCtx *TargetContext) ref
 			Elems: make([]schema.Constraint, len(elemTypes)),
 		}
 		for i, elemType := range elemTypes {
+			if elemType == cty.DynamicPseudoType {
+				// element of unknown type, e.g. a reference
+				cons.Elems[i] = schema.AnyExpression{
+					OfType: elemType,
+				}
+				continue
+			}
 			cons.Elems[i] = schema.LiteralType{
 				Type: elemType,
 			}
@@ -118,3 +128,26 @@ func (a Any) ReferenceTargets(ctx context.Context, targetCtx *TargetContext) ref
 
 	return reference.Targets{}
 }
+
+// tupleTypeOfTupleConsExpr returns a tuple type with the same number
+// of elements as the given tuple literal, such that each element
+// can be targeted, even if some of them cannot be evaluated
+// (e.g. because they contain references)
+func tupleTypeOfTupleConsExpr(expr hcl.Expression) (cty.Type, bool) {
+	tupleExpr, ok := expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return cty.NilType, false
+	}
+
+	elemTypes := make([]cty.Type, len(tupleExpr.Exprs))
+	for i, elemExpr := range tupleExpr.Exprs {
+		elemTypes[i] = cty.DynamicPseudoType
+
+		val, diags := elemExpr.Value(&hcl.EvalContext{})
+		if !diags.HasErrors() {
+			elemTypes[i] = val.Type()
+		}
+	}
+
+	return cty.Tuple(elemTypes), true
+}
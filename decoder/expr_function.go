@@ -35,14 +35,14 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 			End:      pos,
 		}
 
-		return fe.matchingFunctions("", editRange)
+		return fe.matchingFunctions(ctx, "", editRange)
 	}
 
 	switch eType := fe.expr.(type) {
 	case *hclsyntax.ScopeTraversalExpr:
 		if len(eType.Traversal) > 1 {
 			// We assume that function names cannot contain dots
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		prefixLen := pos.Byte - eType.Traversal.SourceRange().Start.Byte
@@ -51,11 +51,11 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 		// There can be a single segment with trailing dot which cannot
 		// be a function anymore as functions cannot contain dots.
 		if prefixLen < 0 || prefixLen > len(rootName) {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		prefix := rootName[0:prefixLen]
-		return fe.matchingFunctions(prefix, eType.Range())
+		return fe.matchingFunctions(ctx, prefix, eType.Range())
 
 	case *hclsyntax.ExprSyntaxError:
 		// Note: this range can range up until the end of the file in case of invalid config
@@ -90,27 +90,27 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 					Start: hcl.Pos{
 						Line:   pos.Line, // we don't recover newlines, so we can keep the original line number
 						Byte:   pos.Byte - len(recoveredPrefixBytes),
-						Column: pos.Column - len(recoveredPrefixBytes),
+						Column: pos.Column - utf8.RuneCount(recoveredPrefixBytes),
 					},
 					End: hcl.Pos{
 						Line:   pos.Line,
 						Byte:   pos.Byte + len(recoveredSuffixBytes),
-						Column: pos.Column + len(recoveredSuffixBytes),
+						Column: pos.Column + utf8.RuneCount(recoveredSuffixBytes),
 					},
 				}
 
-				return fe.matchingFunctions(string(recoveredPrefixBytes), editRange)
+				return fe.matchingFunctions(ctx, string(recoveredPrefixBytes), editRange)
 			}
 		}
 
-		return []lang.Candidate{}
+		return noCandidates()
 
 	case *hclsyntax.FunctionCallExpr:
 		if eType.NameRange.ContainsPos(pos) {
 			prefixLen := pos.Byte - eType.NameRange.Start.Byte
 			prefix := eType.Name[0:prefixLen]
 			editRange := eType.Range()
-			return fe.matchingFunctions(prefix, editRange)
+			return fe.matchingFunctions(ctx, prefix, editRange)
 		}
 
 		f, ok := fe.pathCtx.Functions[eType.Name]
@@ -144,7 +144,7 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 					param = *f.VarParam
 				} else {
 					// Too many arguments passed to the function
-					return []lang.Candidate{}
+					return noCandidates()
 				}
 
 				cons := newExpression(fe.pathCtx, arg, schema.AnyExpression{OfType: param.Type})
@@ -179,13 +179,13 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 			param = *f.VarParam
 		} else {
 			// Too many arguments passed to the function
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cons := newExpression(fe.pathCtx, elemExpr, schema.AnyExpression{OfType: param.Type})
 		return cons.CompletionAtPos(ctx, pos)
 	}
-	return []lang.Candidate{}
+	return noCandidates()
 }
 
 func (fe functionExpr) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
@@ -315,7 +315,7 @@ func (fe functionExpr) ReferenceOrigins(ctx context.Context) reference.Origins {
 	return origins
 }
 
-func (fe functionExpr) matchingFunctions(prefix string, editRange hcl.Range) []lang.Candidate {
+func (fe functionExpr) matchingFunctions(ctx context.Context, prefix string, editRange hcl.Range) []lang.Candidate {
 	candidates := make([]lang.Candidate, 0)
 
 	for name, f := range fe.pathCtx.Functions {
@@ -327,17 +327,23 @@ func (fe functionExpr) matchingFunctions(prefix string, editRange hcl.Range) []l
 			continue
 		}
 
-		candidates = append(candidates, lang.Candidate{
+		candidate := lang.Candidate{
 			Label:       name,
 			Detail:      fmt.Sprintf("%s(%s) %s", name, parameterNamesAsString(f), f.ReturnType.FriendlyName()),
 			Kind:        lang.FunctionCandidateKind,
 			Description: lang.Markdown(f.Description),
 			TextEdit: lang.TextEdit{
 				NewText: fmt.Sprintf("%s()", name),
-				Snippet: fmt.Sprintf("%s(${0})", name),
+				Snippet: fmt.Sprintf("%s(%s)", name, parameterSnippet(f)),
 				Range:   editRange,
 			},
-		})
+		}
+		// signature help is only useful if there are parameters to fill in
+		if len(f.Params) > 0 || f.VarParam != nil {
+			candidate.Command = signatureHelpCommand(ctx)
+		}
+
+		candidates = append(candidates, candidate)
 	}
 
 	sort.SliceStable(candidates, func(i, j int) bool {
@@ -347,6 +353,44 @@ func (fe functionExpr) matchingFunctions(prefix string, editRange hcl.Range) []l
 	return candidates
 }
 
+type signatureHelpCommandIDCtxKey struct{}
+
+func withSignatureHelpCommandID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, signatureHelpCommandIDCtxKey{}, id)
+}
+
+// signatureHelpCommand returns the command which asks the client
+// to request signature help, if one is configured
+// (see CompletionOptions.SignatureHelpCommandID)
+func signatureHelpCommand(ctx context.Context) *lang.Command {
+	id, ok := ctx.Value(signatureHelpCommandIDCtxKey{}).(string)
+	if !ok || id == "" {
+		return nil
+	}
+	return &lang.Command{
+		Title: "Trigger Signature Help",
+		ID:    id,
+	}
+}
+
+// parameterSnippet returns a snippet with a placeholder for each
+// parameter of the given function, including the variadic one.
+func parameterSnippet(fs schema.FunctionSignature) string {
+	if len(fs.Params) == 0 && fs.VarParam == nil {
+		return "${0}"
+	}
+
+	placeholders := make([]string, 0, len(fs.Params)+1)
+	for i, p := range fs.Params {
+		placeholders = append(placeholders, fmt.Sprintf("${%d:%s}", i+1, escapeSnippet(p.Name)))
+	}
+	if fs.VarParam != nil {
+		placeholders = append(placeholders, fmt.Sprintf("${%d:%s}", len(fs.Params)+1, escapeSnippet(fs.VarParam.Name)))
+	}
+
+	return strings.Join(placeholders, ", ")
+}
+
 func hoverContentForFunction(name string, funcSig schema.FunctionSignature) lang.MarkupContent {
 	rawMd := fmt.Sprintf("```terraform\n%s(%s) %s\n```\n\n%s",
 		name, parameterNamesAsString(funcSig), funcSig.ReturnType.FriendlyName(), funcSig.Description)
@@ -35,11 +35,11 @@ func (kw Keyword) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 
 	eType, ok := kw.expr.(*hclsyntax.ScopeTraversalExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	if len(eType.Traversal) != 1 {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	prefixLen := pos.Byte - eType.Traversal.SourceRange().Start.Byte
@@ -47,7 +47,7 @@ func (kw Keyword) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		// The user has probably typed an extra character, such as a
 		// period, that is not (yet) part of the expression. This prefix
 		// won't match anything, so we'll return early.
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 	prefix := eType.Traversal.RootName()[0:prefixLen]
 
@@ -67,5 +67,5 @@ func (kw Keyword) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		}
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
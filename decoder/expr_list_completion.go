@@ -44,11 +44,11 @@ func (list List) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candid
 
 	eType, ok := list.expr.(*hclsyntax.TupleConsExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	if list.cons.Elem == nil {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	betweenBraces := hcl.Range{
@@ -91,5 +91,5 @@ func (list List) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candid
 		return newExpression(list.pathCtx, expr, list.cons.Elem).CompletionAtPos(ctx, pos)
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
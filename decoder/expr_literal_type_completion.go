@@ -28,15 +28,15 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 			if typ == cty.Bool {
 				return boolLiteralTypeCandidates("", editRange)
 			}
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		if typ == cty.DynamicPseudoType {
-			return []lang.Candidate{}
+			return dynamicLiteralTypeCandidates(editRange, lt.cons.SkipComplexTypes)
 		}
 
 		if lt.cons.SkipComplexTypes {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		return []lang.Candidate{
@@ -60,7 +60,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 	if !lt.cons.SkipComplexTypes && typ.IsListType() {
 		expr, ok := lt.expr.(*hclsyntax.TupleConsExpr)
 		if !ok {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cons := schema.List{
@@ -75,7 +75,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 	if !lt.cons.SkipComplexTypes && typ.IsSetType() {
 		expr, ok := lt.expr.(*hclsyntax.TupleConsExpr)
 		if !ok {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cons := schema.Set{
@@ -90,7 +90,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 	if !lt.cons.SkipComplexTypes && typ.IsTupleType() {
 		expr, ok := lt.expr.(*hclsyntax.TupleConsExpr)
 		if !ok {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		elemTypes := typ.TupleElementTypes()
@@ -109,7 +109,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 	if !lt.cons.SkipComplexTypes && typ.IsMapType() {
 		expr, ok := lt.expr.(*hclsyntax.ObjectConsExpr)
 		if !ok {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cons := schema.Map{
@@ -123,7 +123,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 	if !lt.cons.SkipComplexTypes && typ.IsObjectType() {
 		expr, ok := lt.expr.(*hclsyntax.ObjectConsExpr)
 		if !ok {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cons := schema.Object{
@@ -132,7 +132,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 		return newExpression(lt.pathCtx, expr, cons).CompletionAtPos(ctx, pos)
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
 
 func (lt LiteralType) completeBoolAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
@@ -144,7 +144,7 @@ func (lt LiteralType) completeBoolAtPos(ctx context.Context, pos hcl.Pos) []lang
 			// The user has probably typed an extra character, such as a
 			// period, that is not (yet) part of the expression. This prefix
 			// won't match anything, so we'll return early.
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 		prefix := eType.Traversal.RootName()[0:prefixLen]
 		return boolLiteralTypeCandidates(prefix, eType.Range())
@@ -159,9 +159,98 @@ func (lt LiteralType) completeBoolAtPos(ctx context.Context, pos hcl.Pos) []lang
 			prefix := value[0:prefixLen]
 			return boolLiteralTypeCandidates(prefix, eType.Range())
 		}
+
+	case *hclsyntax.TemplateExpr:
+		// quoted bools are converted by HCL, so we offer
+		// the bare values, replacing the quoted string
+		prefix, ok := quotedLiteralPrefix(eType, pos)
+		if !ok {
+			return noCandidates()
+		}
+		return boolLiteralTypeCandidates(prefix, eType.Range())
+	}
+
+	return noCandidates()
+}
+
+// dynamicLiteralTypeCandidates returns starters for values of each
+// primitive and (unless skipped) structural type, as any type
+// is acceptable where the type is dynamic
+func dynamicLiteralTypeCandidates(editRange hcl.Range, skipComplexTypes bool) []lang.Candidate {
+	detail := cty.DynamicPseudoType.FriendlyNameForConstraint()
+
+	candidates := []lang.Candidate{
+		{
+			Label:  "string",
+			Detail: detail,
+			Kind:   lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `""`,
+				Snippet: `"${1:value}"`,
+				Range:   editRange,
+			},
+		},
+		{
+			Label:  "number",
+			Detail: detail,
+			Kind:   lang.NumberCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "1",
+				Snippet: "${1:1}",
+				Range:   editRange,
+			},
+		},
+		{
+			Label:  "bool",
+			Detail: detail,
+			Kind:   lang.BoolCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "false",
+				Snippet: "${1:false}",
+				Range:   editRange,
+			},
+		},
 	}
 
-	return []lang.Candidate{}
+	if skipComplexTypes {
+		return candidates
+	}
+
+	return append(candidates,
+		lang.Candidate{
+			Label:  "list",
+			Detail: detail,
+			Kind:   lang.ListCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "[  ]",
+				Snippet: "[ ${1} ]",
+				Range:   editRange,
+			},
+			TriggerSuggest: true,
+		},
+		lang.Candidate{
+			Label:  "map",
+			Detail: detail,
+			Kind:   lang.MapCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "{\n  \"key\" = \n}",
+				Snippet: "{\n  \"${1:key}\" = ${2}\n}",
+				Range:   editRange,
+			},
+			TriggerSuggest: true,
+		},
+		lang.Candidate{
+			Label:  "object",
+			Detail: detail,
+			Kind:   lang.ObjectCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "{\n  name = \n}",
+				Snippet: "{\n  ${1:name} = ${2}\n}",
+				Range:   editRange,
+			},
+			TriggerSuggest: true,
+		},
+	)
 }
 
 func boolLiteralTypeCandidates(prefix string, editRange hcl.Range) []lang.Candidate {
@@ -96,6 +96,78 @@ func TestCompletionAtPos_exprLiteralType(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"quoted bool by prefix",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.LiteralType{
+						Type: cty.Bool,
+					},
+				},
+			},
+			`attr = "t"
+`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "true",
+					Detail: cty.Bool.FriendlyNameForConstraint(),
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "true",
+						Snippet: "true",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"empty quoted bool",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.LiteralType{
+						Type: cty.Bool,
+					},
+				},
+			},
+			`attr = ""
+`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: cty.Bool.FriendlyNameForConstraint(),
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "false",
+						Snippet: "false",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						},
+					},
+				},
+				{
+					Label:  "true",
+					Detail: cty.Bool.FriendlyNameForConstraint(),
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "true",
+						Snippet: "true",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						},
+					},
+				},
+			}),
+		},
 		{
 			"bool by prefix with dot",
 			map[string]*schema.AttributeSchema{
@@ -1232,3 +1304,58 @@ func TestCompletionAtPos_exprLiteralType(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprLiteralType_dynamic(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		cons           schema.LiteralType
+		expectedLabels []string
+	}{
+		{
+			"all types",
+			schema.LiteralType{Type: cty.DynamicPseudoType},
+			[]string{"string", "number", "bool", "list", "map", "object"},
+		},
+		{
+			"primitive types only",
+			schema.LiteralType{Type: cty.DynamicPseudoType, SkipComplexTypes: true},
+			[]string{"string", "number", "bool"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"attr": {Constraint: tc.cons},
+				},
+			}
+			f, _ := hclsyntax.ParseConfig([]byte("attr = \n"), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, c := range candidates.List {
+				labels = append(labels, c.Label)
+				isStructural := c.Kind == lang.ListCandidateKind ||
+					c.Kind == lang.MapCandidateKind ||
+					c.Kind == lang.ObjectCandidateKind
+				if c.TriggerSuggest != isStructural {
+					t.Fatalf("unexpected TriggerSuggest for %q: %t", c.Label, c.TriggerSuggest)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
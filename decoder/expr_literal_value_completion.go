@@ -50,17 +50,7 @@ func (lv LiteralValue) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 		return lv.completeBoolAtPos(ctx, pos)
 	}
 
-	editRange := lv.expr.Range()
-	if editRange.End.Line != pos.Line {
-		// account for quotes or brackets that are not closed
-		editRange.End = pos
-	}
-
-	if !editRange.ContainsPos(pos) {
-		// account for trailing character(s) which doesn't appear in AST
-		// such as dot, opening bracket etc.
-		editRange.End = pos
-	}
+	editRange := literalEditRange(lv.expr, pos)
 
 	cd := lv.cons.EmptyCompletionData(ctx, 1, 0)
 	return []lang.Candidate{
@@ -82,6 +72,25 @@ func (lv LiteralValue) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 	// Avoid partial completion inside complex types for now
 }
 
+// literalEditRange returns the range of the expression to be replaced
+// by a literal value, accounting for unclosed quotes or brackets
+// and trailing characters
+func literalEditRange(expr hcl.Expression, pos hcl.Pos) hcl.Range {
+	editRange := expr.Range()
+	if editRange.End.Line != pos.Line {
+		// account for quotes or brackets that are not closed
+		editRange.End = pos
+	}
+
+	if !editRange.ContainsPos(pos) {
+		// account for trailing character(s) which doesn't appear in AST
+		// such as dot, opening bracket etc.
+		editRange.End = pos
+	}
+
+	return editRange
+}
+
 func (lv LiteralValue) completeBoolAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
 	switch eType := lv.expr.(type) {
 
@@ -91,7 +100,7 @@ func (lv LiteralValue) completeBoolAtPos(ctx context.Context, pos hcl.Pos) []lan
 			// The user has probably typed an extra character, such as a
 			// period, that is not (yet) part of the expression. This prefix
 			// won't match anything, so we'll return early.
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 		prefix := eType.Traversal.RootName()[0:prefixLen]
 		return lv.boolLiteralValueCandidates(prefix, eType.Range())
@@ -108,7 +117,7 @@ func (lv LiteralValue) completeBoolAtPos(ctx context.Context, pos hcl.Pos) []lan
 		}
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
 
 func (lv LiteralValue) boolLiteralValueCandidates(prefix string, editRange hcl.Range) []lang.Candidate {
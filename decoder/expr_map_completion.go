@@ -47,7 +47,7 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 
 	eType, ok := m.expr.(*hclsyntax.ObjectConsExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	betweenBraces := hcl.Range{
@@ -64,7 +64,7 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 
 	if betweenBraces.ContainsPos(pos) {
 		if m.cons.Elem == nil {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cData := m.cons.Elem.EmptyCompletionData(ctx, 2, 0)
@@ -125,7 +125,7 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 			}
 			if emptyRange.ContainsPos(pos) {
 				// exit early if we're in empty space between key and value
-				return []lang.Candidate{}
+				return noCandidates()
 			}
 
 			// check if we've just missed the position
@@ -149,7 +149,7 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 					}
 				}
 
-				return []lang.Candidate{}
+				return noCandidates()
 			}
 			if item.ValueExpr.Range().ContainsPos(pos) || item.ValueExpr.Range().End.Byte == pos.Byte {
 				cons := newExpression(m.pathCtx, item.ValueExpr, m.cons.Elem)
@@ -192,7 +192,7 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 			return cons.CompletionAtPos(ctx, pos)
 		}
 
-		return []lang.Candidate{}
+		return noCandidates()
 	}
-	return []lang.Candidate{}
+	return noCandidates()
 }
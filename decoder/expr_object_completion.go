@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
@@ -21,7 +22,7 @@ type declaredAttributes map[string]hcl.Range
 
 func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
 	if isEmptyExpression(obj.expr) {
-		cData := obj.cons.EmptyCompletionData(ctx, 1, 0)
+		cData := obj.cons.EmptyCompletionData(ctx, 1, nestingLevelFromContext(ctx))
 		return []lang.Candidate{
 			{ // TODO: Consider rendering first N elements in Label?
 				Label:       "{…}",
@@ -44,7 +45,7 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 
 	eType, ok := obj.expr.(*hclsyntax.ObjectConsExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	betweenBraces := hcl.Range{
@@ -54,11 +55,11 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 	}
 
 	if !betweenBraces.ContainsPos(pos) {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	if len(obj.cons.Attributes) == 0 {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	editRange := hcl.Range{
@@ -67,6 +68,9 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		End:      pos,
 	}
 
+	// items are nested one level deeper than the object itself
+	itemCtx, itemNestingLevel := nestedItemContext(ctx)
+
 	declared := make(declaredAttributes, 0)
 	recoveryPos := eType.OpenRange.Start
 	var lastItemRange, nextItemRange *hcl.Range
@@ -79,7 +83,7 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		}
 		if emptyRange.ContainsPos(pos) {
 			// exit early if we're in empty space between key and value
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		attrName, attrRange, isRawName := rawObjectKey(item.KeyExpr)
@@ -128,21 +132,21 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 
 				editRange := hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range())
 
-				return objectAttributesToCandidates(ctx, prefix, obj.cons.Attributes, declared, editRange)
+				return objectAttributesToCandidates(ctx, prefix, obj.cons.Attributes, declared, editRange, itemNestingLevel)
 			}
 
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 		if item.ValueExpr.Range().ContainsPos(pos) || item.ValueExpr.Range().End.Byte == pos.Byte {
 			aSchema, ok := obj.cons.Attributes[attrName]
 			if !ok {
 				// unknown attribute
-				return []lang.Candidate{}
+				return noCandidates()
 			}
 
 			cons := newExpression(obj.pathCtx, item.ValueExpr, aSchema.Constraint)
 
-			return cons.CompletionAtPos(ctx, pos)
+			return cons.CompletionAtPos(itemCtx, pos)
 		}
 	}
 
@@ -157,24 +161,24 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		// no terminating character was found which indicates
 		// we're on the same line as an existing item
 		// and we're missing preceding comma
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	if len(trimmedBytes) == 1 && isObjectItemTerminatingRune(rune(trimmedBytes[0])) {
 		// avoid completing on the same line as next item
 		if nextItemRange != nil && nextItemRange.Start.Line == pos.Line {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		// avoid completing on the same line as last item
 		if lastItemRange != nil && lastItemRange.End.Line == pos.Line {
 			// if it is not single-line notation
 			if trimmedBytes[0] != ',' {
-				return []lang.Candidate{}
+				return noCandidates()
 			}
 		}
 
-		return objectAttributesToCandidates(ctx, "", obj.cons.Attributes, declared, editRange)
+		return objectAttributesToCandidates(ctx, "", obj.cons.Attributes, declared, editRange, itemNestingLevel)
 	}
 
 	// trime left side as well now
@@ -202,12 +206,12 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		aSchema, ok := obj.cons.Attributes[attrName]
 		if !ok {
 			// unknown attribute
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		cons := newExpression(obj.pathCtx, emptyExpr, aSchema.Constraint)
 
-		return cons.CompletionAtPos(ctx, pos)
+		return cons.CompletionAtPos(itemCtx, pos)
 	}
 
 	prefix := string(bytes.TrimFunc(trimmedBytes, func(r rune) bool {
@@ -224,7 +228,7 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 	}
 	editRange = objectItemPrefixBasedEditRange(remainingRange, fileBytes, trimmedBytes)
 
-	return objectAttributesToCandidates(ctx, prefix, obj.cons.Attributes, declared, editRange)
+	return objectAttributesToCandidates(ctx, prefix, obj.cons.Attributes, declared, editRange, itemNestingLevel)
 }
 
 func objectItemPrefixBasedEditRange(remainingRange hcl.Range, fileBytes []byte, rawPrefixBytes []byte) hcl.Range {
@@ -243,21 +247,21 @@ func objectItemPrefixBasedEditRange(remainingRange hcl.Range, fileBytes []byte,
 		Start: hcl.Pos{
 			// TODO: Calculate Line+Column for multi-line keys?
 			Line:   remainingRange.Start.Line,
-			Column: remainingRange.Start.Column - len(rawPrefixBytes),
+			Column: remainingRange.Start.Column - utf8.RuneCount(rawPrefixBytes),
 			Byte:   remainingRange.Start.Byte - len(rawPrefixBytes),
 		},
 		End: hcl.Pos{
 			// TODO: Calculate Line+Column for multi-line values?
 			Line:   remainingRange.Start.Line,
-			Column: remainingRange.Start.Column + trimmedOffset,
+			Column: remainingRange.Start.Column + utf8.RuneCount(trimmedRightBytes),
 			Byte:   remainingRange.Start.Byte + trimmedOffset,
 		},
 	}
 }
 
-func objectAttributesToCandidates(ctx context.Context, prefix string, attrs schema.ObjectAttributes, declared declaredAttributes, editRange hcl.Range) []lang.Candidate {
+func objectAttributesToCandidates(ctx context.Context, prefix string, attrs schema.ObjectAttributes, declared declaredAttributes, editRange hcl.Range, nestingLevel int) []lang.Candidate {
 	if len(attrs) == 0 {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	candidates := make([]lang.Candidate, 0)
@@ -274,7 +278,7 @@ func objectAttributesToCandidates(ctx context.Context, prefix string, attrs sche
 			continue
 		}
 
-		candidates = append(candidates, attributeSchemaToCandidate(ctx, name, attrs[name], editRange))
+		candidates = append(candidates, attributeSchemaToCandidate(ctx, name, attrs[name], editRange, nestingLevel))
 	}
 
 	return candidates
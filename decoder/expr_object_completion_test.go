@@ -1274,6 +1274,78 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"multi-line key indented with tabs",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"baz": {
+								IsOptional: true,
+								Constraint: schema.Keyword{
+									Keyword: "keyword",
+								},
+							},
+						},
+					},
+				},
+			},
+			"attr = {\n\t\tbaz\n}\n",
+			hcl.Pos{Line: 2, Column: 5, Byte: 13},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `baz`,
+					Detail: "optional, keyword",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 14},
+						},
+						NewText: `baz`,
+						Snippet: `baz = `,
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"multi-line key followed by multi-byte comment",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"baz": {
+								IsOptional: true,
+								Constraint: schema.Keyword{
+									Keyword: "keyword",
+								},
+							},
+						},
+					},
+				},
+			},
+			"attr = {\n\tba # é\n}\n",
+			hcl.Pos{Line: 2, Column: 4, Byte: 12},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `baz`,
+					Detail: "optional, keyword",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 2, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 8, Byte: 17},
+						},
+						NewText: `baz`,
+						Snippet: `baz = `,
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
 		{
 			"multi-line before attribute same line",
 			map[string]*schema.AttributeSchema{
@@ -1886,6 +1958,86 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 				// as currently we receive ObjectConsExpr w/ zero Items.
 			}),
 		},
+		{
+			"list attribute empty element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"foo": {
+								IsOptional: true,
+								Constraint: schema.List{
+									Elem: schema.Keyword{
+										Keyword: "kw",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`attr = {
+  foo = [  ]
+}
+`,
+			hcl.Pos{Line: 2, Column: 10, Byte: 18},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "kw",
+					Detail: "keyword",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 18},
+							End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
+						},
+						NewText: "kw",
+						Snippet: "kw",
+					},
+				},
+			}),
+		},
+		{
+			"list attribute second element prefix",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"foo": {
+								IsOptional: true,
+								Constraint: schema.List{
+									Elem: schema.Keyword{
+										Keyword: "kw",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`attr = {
+  foo = [ kw, k ]
+}
+`,
+			hcl.Pos{Line: 2, Column: 16, Byte: 24},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "kw",
+					Detail: "keyword",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 23},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 24},
+						},
+						NewText: "kw",
+						Snippet: "kw",
+					},
+				},
+			}),
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
@@ -77,8 +77,8 @@ func (obj Object) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 
 func hoverContentForAttribute(name string, aSchema *schema.AttributeSchema) lang.MarkupContent {
 	value := fmt.Sprintf("**%s** _%s_", name, detailForAttribute(aSchema))
-	if aSchema.Description.Value != "" {
-		value += fmt.Sprintf("\n\n%s", aSchema.Description.Value)
+	if description := descriptionForAttribute(aSchema); description.Value != "" {
+		value += fmt.Sprintf("\n\n%s", description.Value)
 	}
 	return lang.MarkupContent{
 		Kind:  lang.MarkdownKind,
@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"context"
+	"sort"
 
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl/v2"
@@ -13,10 +14,44 @@ import (
 func (oo OneOf) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
 	candidates := make([]lang.Candidate, 0)
 
+	type candidateKey struct {
+		label string
+		kind  lang.CandidateKind
+	}
+	seen := make(map[candidateKey]bool, 0)
+
+	deferred := true
 	for _, con := range oo.cons {
 		expr := newExpression(oo.pathCtx, oo.expr, con)
-		candidates = append(candidates, expr.CompletionAtPos(ctx, pos)...)
+		altCandidates := expr.CompletionAtPos(ctx, pos)
+		if isDeferred(altCandidates) {
+			// the expression is not of this alternative's type
+			continue
+		}
+		deferred = false
+
+		for _, candidate := range altCandidates {
+			// alternatives may overlap, e.g. the same keyword
+			// or reference may be valid in more than one of them
+			key := candidateKey{label: candidate.Label, kind: candidate.Kind}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, candidate)
+		}
 	}
 
+	if deferred {
+		return deferCompletion()
+	}
+
+	// list literal values ahead of references, regardless
+	// of the order of alternatives, for stability
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Kind != lang.ReferenceCandidateKind &&
+			candidates[j].Kind == lang.ReferenceCandidateKind
+	})
+
 	return candidates
 }
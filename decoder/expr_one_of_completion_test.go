@@ -10,9 +10,11 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestCompletionAtPos_exprOneOf(t *testing.T) {
@@ -217,3 +219,146 @@ func TestCompletionAtPos_exprOneOf(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprOneOf_literalAndReference(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.OneOf{
+					schema.LiteralType{Type: cty.Bool},
+					schema.Reference{OfType: cty.Bool},
+				},
+			},
+		},
+	}
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "local"},
+				lang.AttrStep{Name: "foo"},
+			},
+			Type: cty.Bool,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "local"},
+				lang.AttrStep{Name: "bar"},
+			},
+			Type: cty.List(cty.String),
+		},
+	}
+
+	cfg := `attr = 
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: refTargets,
+	})
+
+	ctx := context.Background()
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+		End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "false",
+			Detail: "bool",
+			Kind:   lang.BoolCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "false",
+				Snippet: "false",
+				Range:   editRng,
+			},
+		},
+		{
+			Label:  "true",
+			Detail: "bool",
+			Kind:   lang.BoolCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "true",
+				Snippet: "true",
+				Range:   editRng,
+			},
+		},
+		{
+			Label:  "local.foo",
+			Detail: "bool",
+			Kind:   lang.ReferenceCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "local.foo",
+				Snippet: "local.foo",
+				Range:   editRng,
+			},
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestCompletionAtPos_exprOneOf_referenceFirstDuplicates(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.OneOf{
+					schema.Reference{OfType: cty.Bool},
+					schema.LiteralType{Type: cty.Bool},
+					schema.Reference{OfType: cty.DynamicPseudoType},
+				},
+			},
+		},
+	}
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "local"},
+				lang.AttrStep{Name: "foo"},
+			},
+			Type: cty.Bool,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "local"},
+				lang.AttrStep{Name: "bar"},
+			},
+			Type: cty.List(cty.String),
+		},
+	}
+
+	cfg := `attr = 
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: refTargets,
+	})
+
+	ctx := context.Background()
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := make([]string, 0)
+	for _, c := range candidates.List {
+		labels = append(labels, c.Label)
+	}
+	expectedLabels := []string{"false", "true", "local.foo", "local.bar"}
+	if diff := cmp.Diff(expectedLabels, labels); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
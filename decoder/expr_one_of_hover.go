@@ -5,9 +5,17 @@ package decoder
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 func (oo OneOf) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
@@ -16,10 +24,245 @@ func (oo OneOf) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 		// we just pick the first which returns some data
 		expr := newExpression(oo.pathCtx, oo.expr, con)
 		hoverData := expr.HoverAtPos(ctx, pos)
-		if hoverData != nil {
+		if hoverData == nil {
+			continue
+		}
+
+		// alternatives are only relevant when hovering
+		// the whole expression, not any part of it
+		if hoverData.Range != oo.expr.Range() {
 			return hoverData
 		}
+
+		// the data above does not imply the expression satisfies
+		// the constraint, so we only highlight the alternative
+		// which the expression is known to satisfy (if any)
+		matchIdx := -1
+		for i, candidate := range oo.cons {
+			if oo.isSatisfiedBy(candidate) {
+				matchIdx = i
+				break
+			}
+		}
+
+		alternatives := oneOfAlternativesContent(oo.cons, matchIdx)
+		if alternatives == "" {
+			return hoverData
+		}
+
+		return &lang.HoverData{
+			Content: lang.Markdown(hoverData.Content.Value + "\n\n" + alternatives),
+			Range:   hoverData.Range,
+		}
 	}
 
 	return nil
 }
+
+// isSatisfiedBy reports whether the expression is known to satisfy
+// the given alternative, i.e. it is a matching keyword, a literal value
+// of a matching type or a reference to a target of a matching type.
+func (oo OneOf) isSatisfiedBy(con schema.Constraint) bool {
+	switch c := con.(type) {
+	case schema.Keyword:
+		return hcl.ExprAsKeyword(oo.expr) == c.Keyword
+	case schema.LiteralValue:
+		val, ok := staticValue(oo.expr)
+		if !ok {
+			return false
+		}
+		val, err := convert.Convert(val, c.Value.Type())
+		return err == nil && val.RawEquals(c.Value)
+	case schema.Reference:
+		target, ok := oo.referenceTarget()
+		return ok && target.MatchesConstraint(c)
+	case schema.AnyExpression:
+		if target, ok := oo.referenceTarget(); ok {
+			return target.Type != cty.NilType &&
+				target.Type != cty.DynamicPseudoType &&
+				target.IsConvertibleToType(c.OfType)
+		}
+	}
+
+	tc, ok := con.(schema.TypeAwareConstraint)
+	if !ok {
+		return false
+	}
+	typ, ok := tc.ConstraintType()
+	if !ok {
+		return false
+	}
+	val, ok := staticValue(oo.expr)
+	if !ok {
+		return false
+	}
+	_, err := convert.Convert(val, typ)
+	return err == nil
+}
+
+// referenceTarget returns the target of the expression
+// if the expression is a reference to a known target
+func (oo OneOf) referenceTarget() (reference.Target, bool) {
+	eType, ok := oo.expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return reference.Target{}, false
+	}
+
+	origins, ok := oo.pathCtx.ReferenceOrigins.AtPos(eType.Range().Filename, eType.Range().Start)
+	if !ok {
+		return reference.Target{}, false
+	}
+	for _, origin := range origins {
+		matchableOrigin, ok := origin.(reference.MatchableOrigin)
+		if !ok {
+			continue
+		}
+		targets, ok := oo.pathCtx.ReferenceTargets.Match(matchableOrigin)
+		if ok {
+			return targets[0], true
+		}
+	}
+
+	return reference.Target{}, false
+}
+
+// staticValue returns the value of the expression
+// if it can be evaluated without any context
+func staticValue(expr hcl.Expression) (cty.Value, bool) {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return cty.NilVal, false
+	}
+	return val, true
+}
+
+// oneOfAlternativesContent returns a Markdown list of all distinct
+// alternatives. The matching one (at matchIdx) is highlighted and listed
+// first, unless matchIdx is negative. Empty string is returned if there
+// is just one distinct alternative.
+//
+// Enum-like alternatives (literal values only) are listed as allowed
+// values in their declared order instead.
+func oneOfAlternativesContent(cons schema.OneOf, matchIdx int) string {
+	if isLiteralValueEnum(cons) {
+		return oneOfAllowedValuesContent(cons, matchIdx)
+	}
+
+	labels := make([]string, 0, len(cons))
+	seen := make(map[string]bool, len(cons))
+	matchLabel := ""
+	if matchIdx >= 0 {
+		matchLabel = oneOfAlternativeLabel(cons[matchIdx])
+		labels = append(labels, matchLabel)
+		seen[matchLabel] = true
+	}
+	for _, con := range cons {
+		label := oneOfAlternativeLabel(con)
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	if len(labels) < 2 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("One of:")
+	for _, label := range labels {
+		if matchLabel != "" && label == matchLabel {
+			fmt.Fprintf(&sb, "\n- **%s**", label)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n- %s", label)
+	}
+	return sb.String()
+}
+
+// isLiteralValueEnum reports whether all alternatives
+// are literal values of a primitive type
+func isLiteralValueEnum(cons schema.OneOf) bool {
+	for _, con := range cons {
+		lv, ok := con.(schema.LiteralValue)
+		if !ok || !lv.Value.Type().IsPrimitiveType() {
+			return false
+		}
+	}
+	return len(cons) > 0
+}
+
+// oneOfAllowedValuesContent returns a Markdown list of all distinct
+// allowed values with the matching one (at matchIdx) highlighted,
+// unless matchIdx is negative
+func oneOfAllowedValuesContent(cons schema.OneOf, matchIdx int) string {
+	matchText := ""
+	if matchIdx >= 0 {
+		matchText = literalValueText(cons[matchIdx].(schema.LiteralValue).Value)
+	}
+	texts := make([]string, 0, len(cons))
+	seen := make(map[string]bool, len(cons))
+	for _, con := range cons {
+		text := literalValueText(con.(schema.LiteralValue).Value)
+		if seen[text] {
+			continue
+		}
+		seen[text] = true
+		texts = append(texts, text)
+	}
+	if len(texts) < 2 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Allowed values:")
+	for _, text := range texts {
+		if text == matchText {
+			fmt.Fprintf(&sb, "\n- **`%s`**", text)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n- `%s`", text)
+	}
+	return sb.String()
+}
+
+// literalValueText returns the value as it would be written in HCL
+func literalValueText(val cty.Value) string {
+	return string(hclwrite.TokensForValue(val).Bytes())
+}
+
+func oneOfAlternativeLabel(con schema.Constraint) string {
+	label := fmt.Sprintf("_%s_", con.FriendlyName())
+
+	switch c := con.(type) {
+	case schema.Keyword:
+		return fmt.Sprintf("`%s` %s", c.Keyword, label)
+	case schema.LiteralValue:
+		return fmt.Sprintf("`%s` %s", literalValueText(c.Value), label)
+	case schema.Reference:
+		if c.Name != "" {
+			return label
+		}
+		label = "_reference_"
+		if scopeIds := c.ScopeIds(); len(scopeIds) > 0 {
+			quoted := make([]string, len(scopeIds))
+			for i, scopeId := range scopeIds {
+				quoted[i] = fmt.Sprintf("`%s`", scopeId)
+			}
+			label += fmt.Sprintf(" to %s", strings.Join(quoted, " or "))
+		}
+		if c.OfType != cty.NilType {
+			label += fmt.Sprintf(" (%s)", c.OfType.FriendlyNameForConstraint())
+		}
+		return label
+	}
+
+	if tc, ok := con.(schema.TypeAwareConstraint); ok {
+		typ, ok := tc.ConstraintType()
+		if ok && typ != cty.DynamicPseudoType && typ.FriendlyNameForConstraint() != con.FriendlyName() {
+			label += fmt.Sprintf(" (%s)", typ.FriendlyNameForConstraint())
+		}
+	}
+
+	return label
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestHoverAtPos_exprOneOf(t *testing.T) {
@@ -43,7 +44,7 @@ func TestHoverAtPos_exprOneOf(t *testing.T) {
 			`attr = keyword1`,
 			hcl.Pos{Line: 1, Column: 11, Byte: 10},
 			&lang.HoverData{
-				Content: lang.Markdown("`keyword1` _keyword_"),
+				Content: lang.Markdown("`keyword1` _keyword_\n\nOne of:\n- **`keyword1` _keyword_**\n- `keyword2` _keyword_\n- `keyword3` _keyword_"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -68,7 +69,7 @@ func TestHoverAtPos_exprOneOf(t *testing.T) {
 			`attr = keyword2`,
 			hcl.Pos{Line: 1, Column: 11, Byte: 10},
 			&lang.HoverData{
-				Content: lang.Markdown("`keyword2` _keyword_"),
+				Content: lang.Markdown("`keyword2` _keyword_\n\nOne of:\n- **`keyword2` _keyword_**\n- `keyword1` _keyword_"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
@@ -76,6 +77,109 @@ func TestHoverAtPos_exprOneOf(t *testing.T) {
 				},
 			},
 		},
+		{
+			"matching mixed alternatives",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.OneOf{
+						schema.Keyword{
+							Keyword: "auto",
+						},
+						schema.LiteralType{
+							Type: cty.Number,
+						},
+					},
+				},
+			},
+			`attr = 42`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("_number_\n\nOne of:\n- **_number_**\n- `auto` _keyword_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				},
+			},
+		},
+		{
+			"matching enum-like literal values",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.OneOf{
+						schema.LiteralValue{
+							Value: cty.StringVal("gp2"),
+						},
+						schema.LiteralValue{
+							Value: cty.StringVal("gp3"),
+						},
+						schema.LiteralValue{
+							Value: cty.StringVal("io1"),
+						},
+					},
+				},
+			},
+			`attr = "gp3"`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			&lang.HoverData{
+				Content: lang.Markdown("_string_\n\nAllowed values:\n- `\"gp2\"`\n- **`\"gp3\"`**\n- `\"io1\"`"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+				},
+			},
+		},
+		{
+			"matching literal value among mixed alternatives",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.OneOf{
+						schema.LiteralValue{
+							Value: cty.NumberIntVal(42),
+						},
+						schema.Keyword{
+							Keyword: "auto",
+						},
+					},
+				},
+			},
+			`attr = 42`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("_number_\n\nOne of:\n- **`42` _number_**\n- `auto` _keyword_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				},
+			},
+		},
+		{
+			"no alternative known to be satisfied",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.OneOf{
+						schema.Keyword{
+							Keyword: "auto",
+						},
+						schema.LiteralType{
+							Type: cty.List(cty.String),
+						},
+					},
+				},
+			},
+			`attr = [ foo ]`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			&lang.HoverData{
+				Content: lang.Markdown("_list of string_\n\nOne of:\n- `auto` _keyword_\n- _list of string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+				},
+			},
+		},
 		{
 			"no matching expr",
 			map[string]*schema.AttributeSchema{
@@ -5,27 +5,30 @@ package decoder
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
 	if ref.cons.Address != nil {
 		// no candidates if traversal itself is addressable
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	if ref.pathCtx.ReferenceTargets == nil {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	file := ref.pathCtx.Files[ref.expr.Range().Filename]
 	rootBody, ok := file.Body.(*hclsyntax.Body)
 	if !ok {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	outerBodyRng := rootBody.Range()
@@ -57,6 +60,7 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 					Snippet: address,
 					Range:   editRng,
 				},
+				TriggerSuggestChars: referenceTriggerSuggestChars(target),
 			})
 			return nil
 		})
@@ -90,13 +94,16 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 			End:      pos,
 		}
 	default:
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	prefix := string(prefixRng.SliceBytes(file.Bytes))
 
+	// allow completing into attributes of object-typed targets
+	targets := withTypeNestedTargets(ref.pathCtx.ReferenceTargets, prefix)
+
 	candidates := make([]lang.Candidate, 0)
-	ref.pathCtx.ReferenceTargets.MatchWalk(ctx, ref.cons, prefix, outerBodyRng, editRng, func(target reference.Target) error {
+	targets.MatchWalk(ctx, ref.cons, prefix, outerBodyRng, editRng, func(target reference.Target) error {
 		address := target.Address(ctx, editRng.Start).String()
 
 		candidates = append(candidates, lang.Candidate{
@@ -109,8 +116,139 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 				Snippet: address,
 				Range:   editRng,
 			},
+			TriggerSuggestChars: referenceTriggerSuggestChars(target),
 		})
 		return nil
 	})
 	return candidates
 }
+
+// referenceTriggerSuggestChars returns the characters which should
+// reopen completion after inserting a reference to the target,
+// i.e. the dot for nested attributes and the opening bracket
+// for nested elements
+func referenceTriggerSuggestChars(target reference.Target) []string {
+	attrStep, indexStep := false, false
+	for _, nestedTarget := range target.NestedTargets {
+		addr := nestedTarget.Addr
+		if len(addr) == 0 {
+			addr = nestedTarget.LocalAddr
+		}
+		if len(addr) == 0 {
+			continue
+		}
+		switch addr[len(addr)-1].(type) {
+		case lang.AttrStep:
+			attrStep = true
+		case lang.IndexStep:
+			indexStep = true
+		}
+	}
+	if len(target.NestedTargets) == 0 && target.Type != cty.NilType &&
+		target.Type.IsObjectType() && len(target.Type.AttributeTypes()) > 0 {
+		attrStep = true
+	}
+
+	var chars []string
+	if attrStep {
+		chars = append(chars, ".")
+	}
+	if indexStep {
+		chars = append(chars, "[")
+	}
+	return chars
+}
+
+// withTypeNestedTargets returns the targets where any target of object
+// type without nested targets is expanded into nested targets for each
+// attribute of the type, such as var.config.region.
+//
+// Only targets which the prefix completes into (e.g. var.config.)
+// are expanded and the targets are copied only where expanded,
+// so that the whole tree is not walked on every request.
+//
+// Map types are not expanded as keys are not known from the type
+// and targets do not carry values to derive known keys from.
+func withTypeNestedTargets(targets reference.Targets, prefix string) reference.Targets {
+	expanded, _ := expandTypeNestedTargets(targets, prefix)
+	return expanded
+}
+
+func expandTypeNestedTargets(targets reference.Targets, prefix string) (reference.Targets, bool) {
+	var expanded reference.Targets
+	for i, target := range targets {
+		if !prefixCompletesIntoTarget(prefix, target) {
+			continue
+		}
+
+		var nestedTargets reference.Targets
+		if len(target.NestedTargets) > 0 {
+			var ok bool
+			nestedTargets, ok = expandTypeNestedTargets(target.NestedTargets, prefix)
+			if !ok {
+				continue
+			}
+		} else if target.Type.IsObjectType() {
+			nestedTargets = nestedTargetsForObjectType(target)
+		} else {
+			continue
+		}
+
+		if expanded == nil {
+			expanded = make(reference.Targets, len(targets))
+			copy(expanded, targets)
+		}
+		target.NestedTargets = nestedTargets
+		expanded[i] = target
+	}
+
+	if expanded == nil {
+		return targets, false
+	}
+	return expanded, true
+}
+
+// prefixCompletesIntoTarget returns true if the prefix extends
+// the address of the target, e.g. var.config. for var.config
+func prefixCompletesIntoTarget(prefix string, target reference.Target) bool {
+	for _, addr := range []lang.Address{target.Addr, target.LocalAddr} {
+		if len(addr) == 0 {
+			continue
+		}
+		addrStr := addr.String()
+		if strings.HasPrefix(prefix, addrStr+".") || strings.HasPrefix(prefix, addrStr+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func nestedTargetsForObjectType(target reference.Target) reference.Targets {
+	attrTypes := target.Type.AttributeTypes()
+	names := make([]string, 0, len(attrTypes))
+	for name := range attrTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nestedTargets := make(reference.Targets, 0, len(names))
+	for _, name := range names {
+		nestedTarget := reference.Target{
+			ScopeId:                target.ScopeId,
+			RangePtr:               target.RangePtr,
+			TargetableFromRangePtr: target.TargetableFromRangePtr,
+			Type:                   attrTypes[name],
+		}
+		if len(target.Addr) > 0 {
+			nestedTarget.Addr = append(target.Addr.Copy(), lang.AttrStep{Name: name})
+		}
+		if len(target.LocalAddr) > 0 {
+			nestedTarget.LocalAddr = append(target.LocalAddr.Copy(), lang.AttrStep{Name: name})
+		}
+		if nestedTarget.Type.IsObjectType() {
+			nestedTarget.NestedTargets = nestedTargetsForObjectType(nestedTarget)
+		}
+		nestedTargets = append(nestedTargets, nestedTarget)
+	}
+	return nestedTargets
+}
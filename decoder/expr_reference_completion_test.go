@@ -244,6 +244,198 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"matching scope after trailing dot",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfScopeId: lang.ScopeId("variable"),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					ScopeId: lang.ScopeId("variable"),
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					ScopeId: lang.ScopeId("local"),
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "local"},
+						lang.AttrStep{Name: "baz"},
+					},
+					ScopeId: lang.ScopeId("variable"),
+				},
+			},
+			`attr = var.`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.foo",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.foo",
+						Snippet: "var.foo",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"multiple matching scopes",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfScopeIds: []lang.ScopeId{
+							lang.ScopeId("variable"),
+							lang.ScopeId("local"),
+						},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					ScopeId: lang.ScopeId("variable"),
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "local"},
+						lang.AttrStep{Name: "bar"},
+					},
+					ScopeId: lang.ScopeId("local"),
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "data"},
+						lang.AttrStep{Name: "baz"},
+					},
+					ScopeId: lang.ScopeId("data"),
+				},
+			},
+			`attr = `,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.foo",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.foo",
+						Snippet: "var.foo",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+				{
+					Label:  "local.bar",
+					Detail: "reference",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "local.bar",
+						Snippet: "local.bar",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"object target attributes after trailing dot",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "config"},
+					},
+					Type: cty.Object(map[string]cty.Type{
+						"region": cty.String,
+						"size":   cty.Number,
+						"nested": cty.Object(map[string]cty.Type{
+							"zone": cty.String,
+						}),
+						"tags": cty.List(cty.String),
+					}),
+				},
+			},
+			`attr = var.config.`,
+			hcl.Pos{Line: 1, Column: 19, Byte: 18},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.config.nested",
+					Detail: "object",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.config.nested",
+						Snippet: "var.config.nested",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						},
+					},
+					TriggerSuggestChars: []string{"."},
+				},
+				{
+					Label:  "var.config.region",
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.config.region",
+						Snippet: "var.config.region",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						},
+					},
+				},
+				{
+					Label:  "var.config.size",
+					Detail: "number",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "var.config.size",
+						Snippet: "var.config.size",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						},
+					},
+				},
+			}),
+		},
 		{
 			"mismatching prefix",
 			map[string]*schema.AttributeSchema{
@@ -308,3 +500,154 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprReference_collectedAddresses(t *testing.T) {
+	resourceAddrRef := schema.Reference{
+		OfScopeId: lang.ScopeId("resource"),
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: schema.Address{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					ScopeId:     lang.ScopeId("resource"),
+					AsReference: true,
+				},
+				Body: &schema.BodySchema{},
+			},
+			"moved": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"from": {Constraint: resourceAddrRef, IsRequired: true},
+						"to":   {Constraint: resourceAddrRef, IsRequired: true},
+					},
+				},
+			},
+		},
+	}
+	cfg := `resource "aws_instance" "old" {}
+resource "aws_instance" "new" {}
+moved {
+  from = aws_instance.old
+  to = 
+}
+`
+
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	targets, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d = testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: targets,
+	})
+
+	ctx := context.Background()
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 5, Column: 8, Byte: 107})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "aws_instance.new",
+			Detail: "reference",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 5, Column: 8, Byte: 107},
+					End:      hcl.Pos{Line: 5, Column: 8, Byte: 107},
+				},
+				NewText: "aws_instance.new",
+				Snippet: "aws_instance.new",
+			},
+			Kind: lang.ReferenceCandidateKind,
+		},
+		{
+			Label:  "aws_instance.old",
+			Detail: "reference",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 5, Column: 8, Byte: 107},
+					End:      hcl.Pos{Line: 5, Column: 8, Byte: 107},
+				},
+				NewText: "aws_instance.old",
+				Snippet: "aws_instance.old",
+			},
+			Kind: lang.ReferenceCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestWithTypeNestedTargets(t *testing.T) {
+	objType := cty.Object(map[string]cty.Type{
+		"region": cty.String,
+	})
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "config"},
+			},
+			Type: objType,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "other"},
+			},
+			Type: objType,
+		},
+	}
+
+	testCases := []struct {
+		prefix                string
+		expectedNestedTargets []int
+	}{
+		{"", []int{0, 0}},
+		{"var.c", []int{0, 0}},
+		{"var.config", []int{0, 0}},
+		{"var.config.", []int{1, 0}},
+		{"var.config.reg", []int{1, 0}},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.prefix), func(t *testing.T) {
+			expanded := withTypeNestedTargets(targets, tc.prefix)
+
+			nestedTargets := make([]int, len(expanded))
+			for j, target := range expanded {
+				nestedTargets[j] = len(target.NestedTargets)
+			}
+			if diff := cmp.Diff(tc.expectedNestedTargets, nestedTargets); diff != "" {
+				t.Fatalf("unexpected nested targets: %s", diff)
+			}
+			for _, target := range targets {
+				if len(target.NestedTargets) > 0 {
+					t.Fatalf("original targets were modified: %#v", target)
+				}
+			}
+		})
+	}
+}
@@ -212,7 +212,64 @@ foo = "noot"
 `,
 			hcl.Pos{Line: 1, Column: 12, Byte: 11},
 			&lang.HoverData{
-				Content: lang.Markdown("`foo.bar` reference"),
+				Content: lang.Markdown("`foo.bar` reference\n\nOne of:\n- **_reference_ to `two`**\n- _reference_ to `one`\n- _reference_ to `three`"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+				},
+			},
+		},
+		{
+			"matching origin and multi-scope target inside set",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.OneOf{
+							schema.Reference{OfScopeIds: []lang.ScopeId{"one", "two"}},
+							schema.Reference{OfScopeId: lang.ScopeId("three")},
+						},
+					},
+				},
+			},
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "foo"},
+						lang.AttrStep{Name: "bar"},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+					Constraints: reference.OriginConstraints{
+						{
+							OfScopeId: lang.ScopeId("two"),
+						},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "foo"},
+						lang.AttrStep{Name: "bar"},
+					},
+					ScopeId: lang.ScopeId("two"),
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 19},
+						End:      hcl.Pos{Line: 2, Column: 13, Byte: 31},
+					},
+				},
+			},
+			`attr = [ foo.bar ]
+foo = "noot"
+`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			&lang.HoverData{
+				Content: lang.Markdown("`foo.bar` reference\n\nOne of:\n- **_reference_ to `one` or `two`**\n- _reference_ to `three`"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
@@ -97,14 +97,26 @@ func originConstraintsFromCons(cons schema.Reference) reference.OriginConstraint
 	// TODO: Remove condition once legacy tests are gone
 	// This is being flagged up as invalid schema
 	// but we tolerate it for legacy tests
-	if cons.OfType == cty.NilType && cons.OfScopeId == "" {
+	scopeIds := cons.ScopeIds()
+	if cons.OfType == cty.NilType && len(scopeIds) == 0 {
 		return reference.OriginConstraints{}
 	}
 
-	return reference.OriginConstraints{
-		{
+	if len(scopeIds) == 0 {
+		return reference.OriginConstraints{
+			{
+				OfType: cons.OfType,
+			},
+		}
+	}
+
+	// an origin may target any of the acceptable scopes
+	originCons := make(reference.OriginConstraints, 0, len(scopeIds))
+	for _, scopeId := range scopeIds {
+		originCons = append(originCons, reference.OriginConstraint{
 			OfType:    cons.OfType,
-			OfScopeId: cons.OfScopeId,
-		},
+			OfScopeId: scopeId,
+		})
 	}
+	return originCons
 }
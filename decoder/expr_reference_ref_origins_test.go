@@ -93,6 +93,38 @@ func TestCollectRefOrigins_exprReference_hcl(t *testing.T) {
 				},
 			},
 		},
+		{
+			"traversal with multiple scopes",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfScopeIds: []lang.ScopeId{"variable", "local"},
+					},
+					IsOptional: true,
+				},
+			},
+			`attr = foo`,
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "foo"},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+					},
+					Constraints: reference.OriginConstraints{
+						{
+							OfScopeId: "variable",
+						},
+						{
+							OfScopeId: "local",
+						},
+					},
+				},
+			},
+		},
 		{
 			"traversal with index steps",
 			map[string]*schema.AttributeSchema{
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func (set Set) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
@@ -44,11 +45,11 @@ func (set Set) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidat
 
 	eType, ok := set.expr.(*hclsyntax.TupleConsExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	if set.cons.Elem == nil {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	betweenBraces := hcl.Range{
@@ -60,14 +61,11 @@ func (set Set) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidat
 	if betweenBraces.ContainsPos(pos) {
 		if len(eType.Exprs) == 0 {
 			expr := newEmptyExpressionAtPos(eType.Range().Filename, pos)
-			return newExpression(set.pathCtx, expr, set.cons.Elem).CompletionAtPos(ctx, pos)
+			candidates := newExpression(set.pathCtx, expr, set.cons.Elem).CompletionAtPos(ctx, pos)
+			return withSetElementDetail(candidates)
 		}
 
-		// TODO: depending on set.cons.Elem (Keyword, LiteralValue, Reference),
-		// filter out declared elements to provide uniqueness as that is the nature of set.
-		// See https://github.com/hashicorp/hcl-lang/issues/225
-
-		for _, elemExpr := range eType.Exprs {
+		for i, elemExpr := range eType.Exprs {
 			// We cannot trust ranges of empty expressions, so we imply
 			// that invalid configuration follows and we stop here
 			// e.g. for completion between commas [keyword, ,keyword]
@@ -79,21 +77,94 @@ func (set Set) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidat
 				break
 			}
 			if elemExpr.Range().ContainsPos(pos) || elemExpr.Range().End.Byte == pos.Byte {
-				return newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
+				candidates := newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
+				return withSetElementDetail(withoutDeclaredSetElements(candidates, eType.Exprs, i))
 			}
 			if pos.Byte-elemExpr.Range().End.Byte == 1 {
 				fileBytes := set.pathCtx.Files[eType.Range().Filename].Bytes
 				trailingRune := fileBytes[elemExpr.Range().End.Byte:pos.Byte][0]
 
 				if trailingRune == '.' {
-					return newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
+					candidates := newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
+					return withSetElementDetail(withoutDeclaredSetElements(candidates, eType.Exprs, i))
 				}
 			}
 		}
 
 		expr := newEmptyExpressionAtPos(eType.Range().Filename, pos)
-		return newExpression(set.pathCtx, expr, set.cons.Elem).CompletionAtPos(ctx, pos)
+		candidates := newExpression(set.pathCtx, expr, set.cons.Elem).CompletionAtPos(ctx, pos)
+		return withSetElementDetail(withoutDeclaredSetElements(candidates, eType.Exprs, -1))
+	}
+
+	return noCandidates()
+}
+
+// withSetElementDetail notes in the detail of each candidate
+// that it completes an element of a set, e.g. "string, set element".
+//
+// Function candidates are left as is, since their detail
+// is the function signature.
+func withSetElementDetail(candidates []lang.Candidate) []lang.Candidate {
+	for i, candidate := range candidates {
+		if candidate.Kind == lang.FunctionCandidateKind {
+			continue
+		}
+		if candidate.Detail == "" {
+			candidates[i].Detail = "set element"
+			continue
+		}
+		candidates[i].Detail = candidate.Detail + ", set element"
+	}
+	return candidates
+}
+
+// withoutDeclaredSetElements filters out candidates which would
+// insert a known value already declared as another element of the set,
+// since sets only keep unique elements.
+//
+// The element at skipIdx (currently being completed) is not considered.
+func withoutDeclaredSetElements(candidates []lang.Candidate, elemExprs []hclsyntax.Expression, skipIdx int) []lang.Candidate {
+	declaredValues := make([]cty.Value, 0)
+	for i, elemExpr := range elemExprs {
+		if i == skipIdx || isEmptyExpression(elemExpr) {
+			continue
+		}
+		val, diags := elemExpr.Value(nil)
+		if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+			continue
+		}
+		declaredValues = append(declaredValues, val)
+	}
+
+	if len(declaredValues) == 0 {
+		return candidates
+	}
+
+	filtered := make([]lang.Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !isDeclaredValue(candidate.TextEdit.NewText, declaredValues) {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	return filtered
+}
+
+func isDeclaredValue(text string, declaredValues []cty.Value) bool {
+	expr, diags := hclsyntax.ParseExpression([]byte(text), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return false
+	}
+
+	for _, declaredVal := range declaredValues {
+		if declaredVal.Type().Equals(val.Type()) && declaredVal.Equals(val).True() {
+			return true
+		}
 	}
 
-	return []lang.Candidate{}
+	return false
 }
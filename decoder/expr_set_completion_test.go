@@ -135,7 +135,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -166,7 +166,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -198,7 +198,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -230,7 +230,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -262,7 +262,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -294,7 +294,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -329,7 +329,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -362,7 +362,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -396,7 +396,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -431,7 +431,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -466,7 +466,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -501,7 +501,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -536,7 +536,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -572,7 +572,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -606,7 +606,7 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
-					Detail: "keyword",
+					Detail: "keyword, set element",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -620,6 +620,82 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"single-line literal type omitting declared element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [ true, ]
+`,
+			hcl.Pos{Line: 1, Column: 16, Byte: 15},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool, set element",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+							End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"single-line literal value omitting declared element matching prefix",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.OneOf{
+							schema.LiteralValue{Value: cty.StringVal("foo")},
+							schema.LiteralValue{Value: cty.StringVal("bar")},
+							schema.LiteralValue{Value: cty.StringVal("baz")},
+						},
+					},
+				},
+			},
+			`attr = [ "bar", "baz" ]
+`,
+			hcl.Pos{Line: 1, Column: 18, Byte: 17},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "foo",
+					Detail: "string, set element",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
+						},
+						NewText: `"foo"`,
+						Snippet: `"foo"`,
+					},
+					Kind: lang.StringCandidateKind,
+				},
+				{
+					Label:  "baz",
+					Detail: "string, set element",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
+						},
+						NewText: `"baz"`,
+						Snippet: `"baz"`,
+					},
+					Kind: lang.StringCandidateKind,
+				},
+			}),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -688,7 +764,7 @@ func TestCompletionAtPos_exprSet_references(t *testing.T) {
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  "var.bar",
-					Detail: "reference",
+					Detail: "reference, set element",
 					Kind:   lang.ReferenceCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
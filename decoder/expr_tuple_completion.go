@@ -55,11 +55,11 @@ func (tuple Tuple) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Cand
 
 	eType, ok := tuple.expr.(*hclsyntax.TupleConsExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return deferCompletion()
 	}
 
 	if len(tuple.cons.Elems) == 0 {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	betweenBraces := hcl.Range{
@@ -69,7 +69,7 @@ func (tuple Tuple) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Cand
 	}
 
 	if !betweenBraces.ContainsPos(pos) {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	if len(eType.Exprs) == 0 {
@@ -78,7 +78,7 @@ func (tuple Tuple) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Cand
 	}
 
 	if len(eType.Exprs) > len(tuple.cons.Elems) {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	lastElemEndPos := eType.OpenRange.Start
@@ -111,12 +111,12 @@ func (tuple Tuple) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Cand
 	}
 
 	if pos.Byte <= lastElemEndPos.Byte {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	if len(eType.Exprs) == len(tuple.cons.Elems) {
 		// no more elements to complete, all declared
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	fileBytes := tuple.pathCtx.Files[eType.Range().Filename].Bytes
@@ -126,7 +126,7 @@ func (tuple Tuple) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Cand
 	trimmedBytes := bytes.TrimRight(recoveredBytes, " \t\n")
 
 	if len(trimmedBytes) == 0 {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	nextIdx := len(eType.Exprs)
@@ -28,7 +28,7 @@ func (td TypeDeclaration) CompletionAtPos(ctx context.Context, pos hcl.Pos) []la
 	switch eType := td.expr.(type) {
 	case *hclsyntax.ScopeTraversalExpr:
 		if len(eType.Traversal) != 1 {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		prefixLen := pos.Byte - eType.Range().Start.Byte
@@ -36,7 +36,7 @@ func (td TypeDeclaration) CompletionAtPos(ctx context.Context, pos hcl.Pos) []la
 			// The user has probably typed an extra character, such as a
 			// period, that is not (yet) part of the expression. This prefix
 			// won't match anything, so we'll return early.
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 		prefix := eType.Traversal.RootName()[0:prefixLen]
 
@@ -78,7 +78,7 @@ func (td TypeDeclaration) CompletionAtPos(ctx context.Context, pos hcl.Pos) []la
 					return cons.CompletionAtPos(ctx, pos)
 				}
 
-				return []lang.Candidate{}
+				return noCandidates()
 			}
 
 			if eType.Name == "object" {
@@ -91,7 +91,7 @@ func (td TypeDeclaration) CompletionAtPos(ctx context.Context, pos hcl.Pos) []la
 		}
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
 
 func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *hclsyntax.FunctionCallExpr, pos hcl.Pos) []lang.Candidate {
@@ -106,15 +106,15 @@ func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *h
 	}
 
 	if len(funcExpr.Args) > 1 {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	objExpr, isObject := funcExpr.Args[0].(*hclsyntax.ObjectConsExpr)
 	if !isObject {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 	if !funcExpr.Args[0].Range().ContainsPos(pos) {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	editRange := hcl.Range{
@@ -134,9 +134,8 @@ func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *h
 		remainingBytes := bytes.TrimSpace(betweenBraces.SliceBytes(fileBytes))
 
 		if len(remainingBytes) == 0 {
-			return []lang.Candidate{
-				objectAttributeItemAsCompletionCandidate(editRange),
-			}
+			// attribute names are up to the user
+			return noCandidates()
 		}
 
 		// if last byte is =, then it's incomplete attribute
@@ -147,7 +146,6 @@ func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *h
 	}
 
 	recoveryPos := objExpr.OpenRange.End
-	var lastItemRange, nextItemRange *hcl.Range
 	for _, item := range objExpr.Items {
 		emptyRange := hcl.Range{
 			Filename: objExpr.Range().Filename,
@@ -156,24 +154,19 @@ func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *h
 		}
 		if emptyRange.ContainsPos(pos) {
 			// exit early if we're in empty space between key and value
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 
 		// check if we've just missed the position
 		if pos.Byte < item.KeyExpr.Range().Start.Byte {
 			// enable recovery between last item's end and position
-
-			// record current (next) item so we can avoid
-			// completion on the same line
-			nextItemRange = hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()).Ptr()
 			break
 		}
 
-		lastItemRange = hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()).Ptr()
 		recoveryPos = item.ValueExpr.Range().End
 
 		if item.KeyExpr.Range().ContainsPos(pos) {
-			return []lang.Candidate{}
+			return noCandidates()
 		}
 		if item.ValueExpr.Range().ContainsPos(pos) || item.ValueExpr.Range().End.Byte == pos.Byte {
 			cons := TypeDeclaration{
@@ -192,26 +185,7 @@ func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *h
 	trimmedBytes := bytes.TrimRight(recoveredBytes, " \t")
 
 	if len(trimmedBytes) == 0 {
-		return []lang.Candidate{}
-	}
-
-	if len(trimmedBytes) == 1 && isObjectItemTerminatingRune(rune(trimmedBytes[0])) {
-		// avoid completing on the same line as next item
-		if nextItemRange != nil && nextItemRange.Start.Line == pos.Line {
-			return []lang.Candidate{}
-		}
-
-		// avoid completing on the same line as last item
-		if lastItemRange != nil && lastItemRange.End.Line == pos.Line {
-			// if it is not single-line notation
-			if trimmedBytes[0] != ',' {
-				return []lang.Candidate{}
-			}
-		}
-
-		return []lang.Candidate{
-			objectAttributeItemAsCompletionCandidate(editRange),
-		}
+		return noCandidates()
 	}
 
 	// if last byte is =, then it's incomplete attribute
@@ -220,7 +194,7 @@ func (td TypeDeclaration) objectCompletionAtPos(ctx context.Context, funcExpr *h
 		return allTypeDeclarationsAsCandidates("", editRange)
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
 
 func (td TypeDeclaration) tupleCompletionAtPos(ctx context.Context, funcExpr *hclsyntax.FunctionCallExpr, pos hcl.Pos) []lang.Candidate {
@@ -236,12 +210,12 @@ func (td TypeDeclaration) tupleCompletionAtPos(ctx context.Context, funcExpr *hc
 
 	if len(funcExpr.Args) != 1 {
 		// tuple types have to be wrapped in []
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	tupleExpr, ok := funcExpr.Args[0].(*hclsyntax.TupleConsExpr)
 	if !ok {
-		return []lang.Candidate{}
+		return noCandidates()
 	}
 
 	for _, expr := range tupleExpr.Exprs {
@@ -273,7 +247,7 @@ func (td TypeDeclaration) tupleCompletionAtPos(ctx context.Context, funcExpr *hc
 		return allTypeDeclarationsAsCandidates("", editRange)
 	}
 
-	return []lang.Candidate{}
+	return noCandidates()
 }
 
 func allTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []lang.Candidate {
@@ -291,7 +265,7 @@ func primitiveTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) [
 		candidates = append(candidates, lang.Candidate{
 			Label:  cty.Bool.FriendlyNameForConstraint(),
 			Detail: cty.Bool.FriendlyNameForConstraint(),
-			Kind:   lang.BoolCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "bool",
 				Snippet: "bool",
@@ -303,7 +277,7 @@ func primitiveTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) [
 		candidates = append(candidates, lang.Candidate{
 			Label:  cty.Number.FriendlyNameForConstraint(),
 			Detail: cty.Number.FriendlyNameForConstraint(),
-			Kind:   lang.NumberCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "number",
 				Snippet: "number",
@@ -315,7 +289,7 @@ func primitiveTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) [
 		candidates = append(candidates, lang.Candidate{
 			Label:  cty.String.FriendlyNameForConstraint(),
 			Detail: cty.String.FriendlyNameForConstraint(),
-			Kind:   lang.StringCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "string",
 				Snippet: "string",
@@ -335,7 +309,7 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 		candidates = append(candidates, lang.Candidate{
 			Label:  "list(…)",
 			Detail: "list",
-			Kind:   lang.ListCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "list()",
 				Snippet: fmt.Sprintf("list(${%d})", 0),
@@ -348,7 +322,7 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 		candidates = append(candidates, lang.Candidate{
 			Label:  "set(…)",
 			Detail: "set",
-			Kind:   lang.SetCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "set()",
 				Snippet: fmt.Sprintf("set(${%d})", 0),
@@ -361,7 +335,7 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 		candidates = append(candidates, lang.Candidate{
 			Label:  "tuple([…])",
 			Detail: "tuple",
-			Kind:   lang.TupleCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "tuple([])",
 				Snippet: fmt.Sprintf("tuple([ ${%d} ])", 0),
@@ -374,7 +348,7 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 		candidates = append(candidates, lang.Candidate{
 			Label:  "map(…)",
 			Detail: "map",
-			Kind:   lang.MapCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "map()",
 				Snippet: fmt.Sprintf("map(${%d})", 0),
@@ -387,7 +361,7 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 		candidates = append(candidates, lang.Candidate{
 			Label:  "object({…})",
 			Detail: "object",
-			Kind:   lang.ObjectCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "object({\n\n})",
 				Snippet: fmt.Sprintf("object({\n  ${%d:name} = ${%d}\n})", 1, 2),
@@ -399,25 +373,12 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 	return candidates
 }
 
-func objectAttributeItemAsCompletionCandidate(editRange hcl.Range) lang.Candidate {
-	return lang.Candidate{
-		Label:  "name = type",
-		Detail: "type",
-		Kind:   lang.AttributeCandidateKind,
-		TextEdit: lang.TextEdit{
-			NewText: "name = ",
-			Snippet: fmt.Sprintf("${%d:name} = ", 1),
-			Range:   editRange,
-		},
-	}
-}
-
 func innerObjectTypeAsCompletionCandidates(editRange hcl.Range) []lang.Candidate {
 	return []lang.Candidate{
 		{
 			Label:  "{…}",
 			Detail: "object",
-			Kind:   lang.ObjectCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "{\n\n}",
 				Snippet: fmt.Sprintf("{\n  ${%d:name} = ${%d}\n}", 1, 2),
@@ -432,7 +393,7 @@ func innerTupleTypeAsCompletionCandidates(editRange hcl.Range) []lang.Candidate
 		{
 			Label:  "[…]",
 			Detail: "tuple",
-			Kind:   lang.TupleCandidateKind,
+			Kind:   lang.KeywordCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "[]",
 				Snippet: "[ ${0} ]",
@@ -69,7 +69,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -83,7 +83,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "set(…)",
 					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: fmt.Sprintf("set(${%d})", 0),
@@ -111,7 +111,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -150,7 +150,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "list(…)",
 					Detail: "list",
-					Kind:   lang.ListCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "list()",
 						Snippet: fmt.Sprintf("list(${%d})", 0),
@@ -178,7 +178,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "[…]",
 					Detail: "tuple",
-					Kind:   lang.TupleCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "[]",
 						Snippet: "[ ${0} ]",
@@ -277,7 +277,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -291,7 +291,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "set(…)",
 					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -332,7 +332,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "{…}",
 					Detail: "object",
-					Kind:   lang.ObjectCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "{\n\n}",
 						Snippet: fmt.Sprintf("{\n  ${%d:name} = ${%d}\n}", 1, 2),
@@ -355,22 +355,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			`attr = object({})
 `,
 			hcl.Pos{Line: 1, Column: 16, Byte: 15},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
-							End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"missing object notation single-line new element inside quoted key name with no equal sign",
@@ -438,7 +423,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -452,7 +437,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "set(…)",
 					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -480,7 +465,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -494,7 +479,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "set(…)",
 					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -530,22 +515,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			`attr = object({ foo = string,  })
 `,
 			hcl.Pos{Line: 1, Column: 29, Byte: 30},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 1, Column: 29, Byte: 30},
-							End:      hcl.Pos{Line: 1, Column: 29, Byte: 30},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"single-line after attribute without comma",
@@ -569,22 +539,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			`attr = object({ foo = string,  , bar = string })
 `,
 			hcl.Pos{Line: 1, Column: 29, Byte: 30},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 1, Column: 29, Byte: 30},
-							End:      hcl.Pos{Line: 1, Column: 29, Byte: 30},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"single-line between attributes without commas",
@@ -612,22 +567,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 2, Column: 3, Byte: 18},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 18},
-							End:      hcl.Pos{Line: 2, Column: 3, Byte: 18},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line object value",
@@ -663,7 +603,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -677,7 +617,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "set(…)",
 					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -707,7 +647,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "string",
 					Detail: "string",
-					Kind:   lang.StringCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "string",
 						Snippet: "string",
@@ -721,7 +661,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 				{
 					Label:  "set(…)",
 					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Kind:   lang.KeywordCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -820,22 +760,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 2, Column: 3, Byte: 18},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 18},
-							End:      hcl.Pos{Line: 2, Column: 3, Byte: 18},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line after attribute",
@@ -850,22 +775,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 3, Column: 3, Byte: 33},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 33},
-							End:      hcl.Pos{Line: 3, Column: 3, Byte: 33},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line after attribute with comma newline",
@@ -880,22 +790,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 3, Column: 3, Byte: 34},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 34},
-							End:      hcl.Pos{Line: 3, Column: 3, Byte: 34},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line after attribute with comma same line",
@@ -909,22 +804,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 2, Column: 17, Byte: 32},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 2, Column: 17, Byte: 32},
-							End:      hcl.Pos{Line: 2, Column: 17, Byte: 32},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line after attribute without comma same line",
@@ -954,22 +834,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 3, Column: 3, Byte: 33},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 33},
-							End:      hcl.Pos{Line: 3, Column: 3, Byte: 33},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line between attributes with comma",
@@ -984,22 +849,7 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 })
 `,
 			hcl.Pos{Line: 2, Column: 17, Byte: 32},
-			lang.CompleteCandidates([]lang.Candidate{
-				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
-					TextEdit: lang.TextEdit{
-						NewText: "name = ",
-						Snippet: fmt.Sprintf("${%d:name} = ", 1),
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 2, Column: 17, Byte: 32},
-							End:      hcl.Pos{Line: 2, Column: 17, Byte: 32},
-						},
-					},
-				},
-			}),
+			lang.CompleteCandidates([]lang.Candidate{}),
 		},
 		{
 			"multi-line inside attribute",
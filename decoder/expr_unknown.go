@@ -13,7 +13,7 @@ import (
 type unknownExpression struct{}
 
 func (oo unknownExpression) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
-	return []lang.Candidate{}
+	return deferCompletion()
 }
 
 func (oo unknownExpression) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
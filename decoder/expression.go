@@ -191,6 +191,32 @@ func newExpression(pathContext *PathContext, expr hcl.Expression, cons schema.Co
 	return unknownExpression{}
 }
 
+// noCandidates returns an explicit empty (non-nil) slice of candidates
+// which signals that the expression was handled, but nothing
+// can be completed at the given position.
+func noCandidates() []lang.Candidate {
+	return []lang.Candidate{}
+}
+
+// deferCompletion returns nil, which is reserved for expressions
+// that are not of the type the handler understands, such as
+// a number where a list is expected. This leaves completion
+// to other handlers, e.g. other alternatives of OneOf.
+//
+// Handlers delegating to a nested expression (e.g. list element)
+// pass its candidates through, so deferral of the nested
+// expression propagates.
+func deferCompletion() []lang.Candidate {
+	return nil
+}
+
+// isDeferred reports whether a handler deferred completion
+// via deferCompletion, as opposed to handling the expression
+// and returning noCandidates.
+func isDeferred(candidates []lang.Candidate) bool {
+	return candidates == nil
+}
+
 // isEmptyExpression returns true if given expression is suspected
 // to be empty, e.g. newline after equal sign.
 //
@@ -354,3 +380,47 @@ func rawObjectKey(expr hcl.Expression) (string, *hcl.Range, bool) {
 
 	return "", nil, false
 }
+
+// quotedLiteralPrefix returns the part of a quoted string literal
+// (without any interpolation) before pos, if pos is inside the quotes.
+//
+// This allows treating legacy quoted values (e.g. "3" or "true")
+// which HCL converts to the expected type as values of that type.
+func quotedLiteralPrefix(expr hcl.Expression, pos hcl.Pos) (string, bool) {
+	tplExpr, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok {
+		return "", false
+	}
+
+	rng := tplExpr.Range()
+	if pos.Byte <= rng.Start.Byte || pos.Byte >= rng.End.Byte {
+		// outside of the quotes
+		return "", false
+	}
+
+	if len(tplExpr.Parts) == 0 {
+		// empty string
+		return "", true
+	}
+	if !tplExpr.IsStringLiteral() {
+		return "", false
+	}
+
+	part, ok := tplExpr.Parts[0].(*hclsyntax.LiteralValueExpr)
+	if !ok || part.Val.Type() != cty.String {
+		return "", false
+	}
+
+	value := part.Val.AsString()
+	if value == "" {
+		// the parser places an empty literal after the closing quote
+		return "", true
+	}
+
+	prefixLen := pos.Byte - part.Range().Start.Byte
+	if prefixLen < 0 || prefixLen > len(value) {
+		return "", false
+	}
+
+	return value[:prefixLen], true
+}
@@ -18,29 +18,175 @@ import (
 
 func (d *PathDecoder) attrValueCompletionAtPos(ctx context.Context, attr *hclsyntax.Attribute, schema *schema.AttributeSchema, outerBodyRng hcl.Range, pos hcl.Pos) (lang.Candidates, error) {
 	candidates := lang.NewCandidates()
-	candidates.IsComplete = true
+	isComplete := true
+
+	if d.decoderCtx.Completion.IndentNestedSnippets {
+		ctx = withNestingLevel(ctx, d.nestingLevelAtPos(attr.Range().Filename, attr.Range().Start))
+	}
 
 	if len(schema.CompletionHooks) > 0 {
-		candidates.IsComplete = false
+		isComplete = false
 		candidates.List = append(candidates.List, d.candidatesFromHooks(ctx, attr, schema, outerBodyRng, pos)...)
 	}
-	count := len(candidates.List)
+	if schema.IsVersionConstraint {
+		candidates.List = append(candidates.List, versionConstraintCandidates(attr.Expr, pos)...)
+	}
+	if schema.AllowedValuesFunc != nil {
+		candidates.List = append(candidates.List, d.candidatesFromAllowedValues(ctx, attr, schema.AllowedValuesFunc, pos)...)
+	}
 
-	if uint(count) < d.maxCandidates {
-		expr := d.newExpression(attr.Expr, schema.Constraint)
-		for _, candidate := range expr.CompletionAtPos(ctx, pos) {
-			if uint(count) >= d.maxCandidates {
-				return candidates, nil
-			}
+	expr := d.newExpression(attr.Expr, schema.Constraint)
+	candidates.List = append(candidates.List, expr.CompletionAtPos(ctx, pos)...)
+
+	if d.decoderCtx.Completion.SuggestInterpolatedReferences && isReferenceConstraint(schema.Constraint) && isEmptyExpression(attr.Expr) {
+		candidates.List = append(candidates.List, interpolatedReferenceCandidates(candidates.List)...)
+	}
 
+	if candidate, ok := defaultNumberCandidate(schema, attr.Expr, pos); ok {
+		candidates.List = append(candidates.List, candidate)
+	}
+
+	if isNullable(schema) {
+		if candidate, ok := nullCandidateAtPos(attr.Expr, pos); ok {
 			candidates.List = append(candidates.List, candidate)
-			count++
 		}
 	}
 
+	if uint(len(candidates.List)) > d.candidateLimit() {
+		// clients order candidates themselves, so sorting
+		// only matters for which candidates survive the limit
+		sort.Stable(candidates)
+	}
+	candidates = d.truncateCandidates(candidates)
+	candidates.IsComplete = candidates.IsComplete && isComplete
+
 	return candidates, nil
 }
 
+// isNullable returns true if null is a meaningful value
+// for the attribute, i.e. if the attribute can be unset
+// or its constraint accepts any expression
+func isNullable(aSchema *schema.AttributeSchema) bool {
+	if aSchema.IsOptional || aSchema.IsNullable {
+		return true
+	}
+	if aSchema.IsRequired {
+		// null would leave the attribute unset
+		return false
+	}
+	_, ok := aSchema.Constraint.(schema.AnyExpression)
+	return ok
+}
+
+// defaultNumberCandidate returns a candidate for the default
+// value of a numeric attribute, as long as the default
+// is within the number range of the attribute (if any).
+//
+// The candidate is offered for an empty value, or inside a quoted
+// value matching the default, which HCL would convert to a number.
+// The quoted value is then replaced with the bare number.
+func defaultNumberCandidate(aSchema *schema.AttributeSchema, expr hclsyntax.Expression, pos hcl.Pos) (lang.Candidate, bool) {
+	editRng := hcl.Range{
+		Filename: expr.Range().Filename,
+		Start:    pos,
+		End:      pos,
+	}
+	prefix := ""
+	if !isEmptyExpression(expr) {
+		quotedPrefix, ok := quotedLiteralPrefix(expr, pos)
+		if !ok {
+			return lang.Candidate{}, false
+		}
+		editRng = expr.Range()
+		prefix = quotedPrefix
+	}
+
+	dv, ok := aSchema.DefaultValue.(schema.DefaultValue)
+	if !ok {
+		return lang.Candidate{}, false
+	}
+	if dv.Value == cty.NilVal || !dv.Value.IsKnown() || dv.Value.IsNull() || !dv.Value.Type().Equals(cty.Number) {
+		return lang.Candidate{}, false
+	}
+	if aSchema.NumberRange != nil && !aSchema.NumberRange.Contains(dv.Value) {
+		return lang.Candidate{}, false
+	}
+
+	text := dv.Value.AsBigFloat().Text('f', -1)
+	if !strings.HasPrefix(text, prefix) {
+		return lang.Candidate{}, false
+	}
+
+	return lang.Candidate{
+		Label:  text,
+		Detail: "default",
+		Kind:   lang.NumberCandidateKind,
+		TextEdit: lang.TextEdit{
+			NewText: text,
+			Snippet: text,
+			Range:   editRng,
+		},
+	}, true
+}
+
+// nullCandidateAtPos returns a candidate for the null keyword
+// if pos is in the top-level value position, i.e. the value
+// is either empty or a prefix of null
+func nullCandidateAtPos(expr hclsyntax.Expression, pos hcl.Pos) (lang.Candidate, bool) {
+	rng := expr.Range()
+
+	if !isEmptyExpression(expr) {
+		eType, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || len(eType.Traversal) != 1 || rng.End.Byte != pos.Byte {
+			return lang.Candidate{}, false
+		}
+		if !strings.HasPrefix("null", eType.Traversal.RootName()) {
+			return lang.Candidate{}, false
+		}
+	} else {
+		rng = hcl.Range{
+			Filename: rng.Filename,
+			Start:    pos,
+			End:      pos,
+		}
+	}
+
+	return lang.Candidate{
+		Label:  "null",
+		Detail: "null",
+		Kind:   lang.KeywordCandidateKind,
+		TextEdit: lang.TextEdit{
+			NewText: "null",
+			Snippet: "null",
+			Range:   rng,
+		},
+	}, true
+}
+
+func isReferenceConstraint(cons schema.Constraint) bool {
+	_, ok := cons.(schema.Reference)
+	return ok
+}
+
+// interpolatedReferenceCandidates returns a copy of each reference
+// candidate with the reference wrapped in a string template,
+// for users accustomed to interpolating references
+func interpolatedReferenceCandidates(candidates []lang.Candidate) []lang.Candidate {
+	wrapped := make([]lang.Candidate, 0)
+	for _, candidate := range candidates {
+		if candidate.Kind != lang.ReferenceCandidateKind {
+			continue
+		}
+
+		text := fmt.Sprintf(`"${%s}"`, candidate.TextEdit.NewText)
+		candidate.Label = text
+		candidate.TextEdit.NewText = text
+		candidate.TextEdit.Snippet = escapeSnippet(text)
+		wrapped = append(wrapped, candidate)
+	}
+	return wrapped
+}
+
 type pathKey struct{}
 
 // WithPath is not intended to be used outside this package
@@ -131,7 +277,7 @@ func (d *PathDecoder) candidatesFromHooks(ctx context.Context, attr *hclsyntax.A
 	ctx = WithPath(ctx, d.path)
 	ctx = WithFilename(ctx, attr.Expr.Range().Filename)
 	ctx = WithPos(ctx, pos)
-	ctx = WithMaxCandidates(ctx, d.maxCandidates)
+	ctx = WithMaxCandidates(ctx, d.candidateLimit())
 
 	count := 0
 	for _, hook := range aSchema.CompletionHooks {
@@ -139,7 +285,7 @@ func (d *PathDecoder) candidatesFromHooks(ctx context.Context, attr *hclsyntax.A
 			res, _ := completionFunc(ctx, cty.StringVal(prefix))
 
 			for _, c := range res {
-				if uint(count) >= d.maxCandidates {
+				if uint(count) >= d.candidateLimit() {
 					return candidates
 				}
 
@@ -166,6 +312,26 @@ func (d *PathDecoder) candidatesFromHooks(ctx context.Context, attr *hclsyntax.A
 	return candidates
 }
 
+func (d *PathDecoder) candidatesFromAllowedValues(ctx context.Context, attr *hclsyntax.Attribute, allowedValuesFunc schema.AllowedValuesFunc, pos hcl.Pos) []lang.Candidate {
+	cons := schema.OneOf{}
+	for _, val := range allowedValuesFunc(ctx) {
+		if !val.IsWhollyKnown() || val.IsNull() {
+			continue
+		}
+		cons = append(cons, schema.LiteralValue{Value: val})
+	}
+
+	candidates := make([]lang.Candidate, 0)
+	for _, candidate := range d.newExpression(attr.Expr, cons).CompletionAtPos(ctx, pos) {
+		if uint(len(candidates)) >= d.candidateLimit() {
+			break
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
 func candidateKindForType(t cty.Type) lang.CandidateKind {
 	if t == cty.Bool {
 		return lang.BoolCandidateKind
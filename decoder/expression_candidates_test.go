@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -192,6 +193,309 @@ func TestLegacyDecoder_CandidateAtPos_expressions_Hooks(t *testing.T) {
 	}
 }
 
+func TestCompletionAtPos_allowedValuesFunc(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Keyword{Keyword: "none"},
+				AllowedValuesFunc: func(ctx context.Context) []cty.Value {
+					return []cty.Value{
+						cty.StringVal("first"),
+						cty.UnknownVal(cty.String),
+						cty.StringVal("second"),
+					}
+				},
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("attr = \n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+		End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "first",
+			Detail: "string",
+			Kind:   lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"first"`,
+				Snippet: `"first"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:  "second",
+			Detail: "string",
+			Kind:   lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"second"`,
+				Snippet: `"second"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:  "none",
+			Detail: "keyword",
+			Kind:   lang.KeywordCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "none",
+				Snippet: "none",
+				Range:   editRng,
+			},
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestCompletionAtPos_versionConstraint(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"terraform": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"required_version": {
+							Constraint:          schema.LiteralType{Type: cty.String},
+							IsOptional:          true,
+							IsVersionConstraint: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := `terraform {
+  required_version = 
+}
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	pos := hcl.Pos{Line: 2, Column: 22, Byte: 33}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    pos,
+		End:      pos,
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:       `">= version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Allows the given version or any newer one"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `">= 1.0.0"`,
+				Snippet: `">= ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:       `"~> version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Allows only the right-most version component to increment"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"~> 1.0.0"`,
+				Snippet: `"~> ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:       `"= version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Allows only the exact given version"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"= 1.0.0"`,
+				Snippet: `"= ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:       `"!= version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Excludes the exact given version"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"!= 1.0.0"`,
+				Snippet: `"!= ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:       `"> version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Allows only versions newer than the given one"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"> 1.0.0"`,
+				Snippet: `"> ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:       `"< version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Allows only versions older than the given one"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"< 1.0.0"`,
+				Snippet: `"< ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:       `"<= version"`,
+			Detail:      "version constraint",
+			Description: lang.PlainText("Allows the given version or any older one"),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: `"<= 1.0.0"`,
+				Snippet: `"<= ${1:1.0.0}"`,
+				Range:   editRng,
+			},
+		},
+		{
+			Label:  "null",
+			Detail: "null",
+			Kind:   lang.KeywordCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "null",
+				Snippet: "null",
+				Range:   editRng,
+			},
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestCompletionAtPos_versionConstraintInString(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"terraform": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"required_version": {
+							Constraint:          schema.LiteralType{Type: cty.String},
+							IsOptional:          true,
+							IsVersionConstraint: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		value          string
+		pos            hcl.Pos
+		expectedLabels []string
+		expectedRange  hcl.Range
+	}{
+		{
+			"empty string",
+			`""`,
+			hcl.Pos{Line: 2, Column: 23, Byte: 34},
+			[]string{`">= version"`, `"~> version"`, `"= version"`, `"!= version"`, `"> version"`, `"< version"`, `"<= version"`},
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 22, Byte: 33},
+				End:      hcl.Pos{Line: 2, Column: 24, Byte: 35},
+			},
+		},
+		{
+			"operator prefix",
+			`">"`,
+			hcl.Pos{Line: 2, Column: 24, Byte: 35},
+			[]string{`">= version"`, `"> version"`},
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 22, Byte: 33},
+				End:      hcl.Pos{Line: 2, Column: 25, Byte: 36},
+			},
+		},
+		{
+			"complete constraint",
+			`"~> 4.0"`,
+			hcl.Pos{Line: 2, Column: 29, Byte: 40},
+			[]string{},
+			hcl.Range{},
+		},
+		{
+			"cursor before complete constraint",
+			`"~> 4.0"`,
+			hcl.Pos{Line: 2, Column: 23, Byte: 34},
+			[]string{},
+			hcl.Range{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			cfg := fmt.Sprintf("terraform {\n  required_version = %s\n}\n", tc.value)
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				if candidate.Detail != "version constraint" {
+					continue
+				}
+				labels = append(labels, candidate.Label)
+				if diff := cmp.Diff(tc.expectedRange, candidate.TextEdit.Range); diff != "" {
+					t.Fatalf("unexpected range of %q: %s", candidate.Label, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestLegacyDecoder_CandidateAtPos_maxCandidates(t *testing.T) {
 	ctx := context.Background()
 	bodySchema := &schema.BodySchema{
@@ -249,3 +553,489 @@ func TestLegacyDecoder_CandidateAtPos_maxCandidates(t *testing.T) {
 		t.Fatalf("unexpected candidates count: %d", count)
 	}
 }
+
+func TestCompletionAtPos_maxCandidatesTruncatedBySortOrder(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.OneOf{
+					schema.Keyword{Keyword: "zulu"},
+					schema.Keyword{Keyword: "mike"},
+					schema.Keyword{Keyword: "alpha"},
+				},
+				IsRequired: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		maxCandidates      uint
+		expectedLabels     []string
+		expectedIsComplete bool
+	}{
+		{
+			"limit above count",
+			3,
+			[]string{"zulu", "mike", "alpha"},
+			true,
+		},
+		{
+			"truncated by sort order",
+			2,
+			[]string{"alpha", "mike"},
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(`attr = `), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.MaxCandidates = tc.maxCandidates
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+			if candidates.IsComplete != tc.expectedIsComplete {
+				t.Fatalf("expected IsComplete: %t, got: %t", tc.expectedIsComplete, candidates.IsComplete)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_null(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"optional_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			"required_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+			},
+			"nullable_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+				IsNullable: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"optional attribute",
+			`optional_attr = 
+`,
+			hcl.Pos{Line: 1, Column: 17, Byte: 16},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
+		},
+		{
+			"optional attribute with prefix",
+			`optional_attr = nu
+`,
+			hcl.Pos{Line: 1, Column: 19, Byte: 18},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
+		},
+		{
+			"required attribute",
+			`required_attr = 
+`,
+			hcl.Pos{Line: 1, Column: 17, Byte: 16},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"required nullable attribute",
+			`nullable_attr = 
+`,
+			hcl.Pos{Line: 1, Column: 17, Byte: 16},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						},
+						NewText: "null",
+						Snippet: "null",
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_interpolatedReferences(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{OfType: cty.String},
+				IsRequired: true,
+			},
+		},
+	}
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "x"},
+			},
+			Type: cty.String,
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"empty value",
+			`attr = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.x",
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "var.x",
+						Snippet: "var.x",
+					},
+				},
+				{
+					Label:  `"${var.x}"`,
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: `"${var.x}"`,
+						Snippet: `"\${var.x\}"`,
+					},
+				},
+			}),
+		},
+		{
+			"bare prefix",
+			`attr = va
+`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "var.x",
+					Detail: "string",
+					Kind:   lang.ReferenceCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						},
+						NewText: "var.x",
+						Snippet: "var.x",
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: refTargets,
+			})
+			d.decoderCtx.Completion.SuggestInterpolatedReferences = true
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_defaultNumber(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"port": {
+				Constraint:   schema.LiteralType{Type: cty.Number},
+				IsRequired:   true,
+				NumberRange:  &schema.NumberRange{Min: 1, Max: 65535},
+				DefaultValue: schema.DefaultValue{Value: cty.NumberIntVal(8080)},
+			},
+			"invalid_port": {
+				Constraint:   schema.LiteralType{Type: cty.Number},
+				IsRequired:   true,
+				NumberRange:  &schema.NumberRange{Min: 1, Max: 65535},
+				DefaultValue: schema.DefaultValue{Value: cty.NumberIntVal(0)},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"attribute name detail",
+			`po
+`,
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "port",
+					Detail: "required, number, 1-65535, default: 8080",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 3, Byte: 2},
+						},
+						NewText: "port",
+						Snippet: "port = ${1:8080}",
+					},
+				},
+			}),
+		},
+		{
+			"default in range",
+			`port = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "8080",
+					Detail: "default",
+					Kind:   lang.NumberCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "8080",
+						Snippet: "8080",
+					},
+				},
+			}),
+		},
+		{
+			"quoted numeric prefix",
+			`port = "80"
+`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "8080",
+					Detail: "default",
+					Kind:   lang.NumberCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						},
+						NewText: "8080",
+						Snippet: "8080",
+					},
+				},
+			}),
+		},
+		{
+			"quoted non-matching prefix",
+			`port = "9"
+`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"default out of range",
+			`invalid_port = 
+`,
+			hcl.Pos{Line: 1, Column: 16, Byte: 15},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_defaultValueSnippet(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"count": {
+				Constraint:   schema.LiteralType{Type: cty.Number},
+				IsOptional:   true,
+				DefaultValue: schema.DefaultValue{Value: cty.NumberIntVal(1)},
+			},
+			"region": {
+				Constraint:   schema.LiteralType{Type: cty.String},
+				IsOptional:   true,
+				DefaultValue: schema.DefaultValue{Value: cty.StringVal("${us}-east")},
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.InitialPos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.InitialPos,
+		End:      hcl.InitialPos,
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "count",
+			Detail: "optional, number, default: 1",
+			Kind:   lang.AttributeCandidateKind,
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "count",
+				Snippet: "count = ${1:1}",
+			},
+		},
+		{
+			Label:  "region",
+			Detail: `optional, string, default: "$${us}-east"`,
+			Kind:   lang.AttributeCandidateKind,
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "region",
+				Snippet: `region = "${1:\$\${us\}-east}"`,
+			},
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
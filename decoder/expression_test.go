@@ -5,14 +5,19 @@ package decoder
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 	"unicode"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
 )
 
 var (
@@ -376,3 +381,74 @@ func TestRawObjectKey_json(t *testing.T) {
 		})
 	}
 }
+
+func TestExpression_CompletionAtPos_noCandidates(t *testing.T) {
+	testCases := []struct {
+		cons schema.Constraint
+		cfg  string
+	}{
+		{schema.AnyExpression{OfType: cty.String}, `attr = 42`},
+		{schema.AnyExpression{OfType: cty.DynamicPseudoType}, `attr = foo[bar]`},
+		{schema.Keyword{Keyword: "bar"}, `attr = foo`},
+		{schema.List{Elem: schema.LiteralType{Type: cty.String}}, `attr = [ 1 ]`},
+		{schema.LiteralType{Type: cty.Bool}, `attr = 42`},
+		{schema.Map{Elem: schema.LiteralType{Type: cty.String}}, `attr = { a = 1 }`},
+		{schema.Object{}, `attr = { a = 1 }`},
+		{schema.OneOf{schema.Keyword{Keyword: "foo"}, schema.List{Elem: schema.LiteralType{Type: cty.String}}}, `attr = [ 1 ]`},
+		{schema.Reference{OfType: cty.String}, `attr = foo`},
+		{schema.Set{Elem: schema.LiteralType{Type: cty.String}}, `attr = [ 1 ]`},
+		{schema.Tuple{Elems: []schema.Constraint{schema.LiteralType{Type: cty.String}}}, `attr = [ 1 ]`},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.cons.FriendlyName()), func(t *testing.T) {
+			candidates := testExpressionCompletionAtEnd(t, tc.cons, tc.cfg)
+			if isDeferred(candidates) {
+				t.Fatalf("expected empty candidates, nil given")
+			}
+			if len(candidates) != 0 {
+				t.Fatalf("expected no candidates, %d given", len(candidates))
+			}
+		})
+	}
+}
+
+func TestExpression_CompletionAtPos_deferred(t *testing.T) {
+	testCases := []struct {
+		cons schema.Constraint
+		cfg  string
+	}{
+		{schema.Keyword{Keyword: "foo"}, `attr = 42`},
+		{schema.List{Elem: schema.LiteralType{Type: cty.String}}, `attr = 42`},
+		{schema.Map{Elem: schema.LiteralType{Type: cty.String}}, `attr = [ 1 ]`},
+		{schema.Object{}, `attr = 42`},
+		{schema.OneOf{schema.Keyword{Keyword: "foo"}, schema.List{Elem: schema.LiteralType{Type: cty.String}}}, `attr = { a = 1 }`},
+		{schema.Reference{OfType: cty.String}, `attr = 42`},
+		{schema.Set{Elem: schema.LiteralType{Type: cty.String}}, `attr = { a = 1 }`},
+		{schema.Tuple{Elems: []schema.Constraint{schema.LiteralType{Type: cty.String}}}, `attr = 42`},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.cons.FriendlyName()), func(t *testing.T) {
+			candidates := testExpressionCompletionAtEnd(t, tc.cons, tc.cfg)
+			if !isDeferred(candidates) {
+				t.Fatalf("expected nil (deferred) candidates, %#v given", candidates)
+			}
+		})
+	}
+}
+
+// testExpressionCompletionAtEnd returns candidates for the expression
+// of attribute "attr" past its end, where no candidates apply
+func testExpressionCompletionAtEnd(t *testing.T, cons schema.Constraint, cfg string) []lang.Candidate {
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	attr := f.Body.(*hclsyntax.Body).Attributes["attr"]
+	pathCtx := &PathContext{
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: reference.Targets{},
+	}
+
+	return newExpression(pathCtx, attr.Expr, cons).CompletionAtPos(context.Background(), attr.Expr.Range().End)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -50,3 +51,80 @@ func forEachReferenceTargets(attr *hcl.Attribute, bodyRange hcl.Range) reference
 		},
 	}
 }
+
+// dynamicIteratorReferenceTargets returns the key and value targets
+// of the iterator of a dynamic block, targetable from within
+// the content block(s) of the given dynamic block.
+//
+// The iterator is named after the block label, unless
+// the iterator attribute provides a different name.
+func dynamicIteratorReferenceTargets(block *hcl.Block) reference.Targets {
+	body, ok := block.Body.(*hclsyntax.Body)
+	if !ok || len(block.Labels) != 1 {
+		return reference.Targets{}
+	}
+
+	name := block.Labels[0]
+	rng := block.LabelRanges[0]
+	defRng := block.LabelRanges[0]
+	if attr, ok := body.Attributes["iterator"]; ok {
+		iteratorName, ok := dynamicIteratorName(attr.Expr)
+		if !ok {
+			return reference.Targets{}
+		}
+		name = iteratorName
+		rng = attr.Range()
+		defRng = attr.NameRange
+	}
+
+	refs := make(reference.Targets, 0)
+	for _, contentBlock := range body.Blocks {
+		if contentBlock.Type != "content" {
+			continue
+		}
+		contentRng := contentBlock.Body.Range()
+
+		refs = append(refs, reference.Target{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: name},
+				lang.AttrStep{Name: "key"},
+			},
+			TargetableFromRangePtr: contentRng.Ptr(),
+			Type:                   cty.DynamicPseudoType,
+			Description:            lang.Markdown("The map key or list element index for the current element"),
+			RangePtr:               rng.Ptr(),
+			DefRangePtr:            defRng.Ptr(),
+		}, reference.Target{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: name},
+				lang.AttrStep{Name: "value"},
+			},
+			TargetableFromRangePtr: contentRng.Ptr(),
+			Type:                   cty.DynamicPseudoType,
+			Description:            lang.Markdown("The value of the current element"),
+			RangePtr:               rng.Ptr(),
+			DefRangePtr:            defRng.Ptr(),
+		})
+	}
+
+	return refs
+}
+
+// dynamicIteratorName returns the iterator name declared
+// either as a bare keyword (iterator = foo) or as a string
+func dynamicIteratorName(expr hclsyntax.Expression) (string, bool) {
+	if name := hcl.ExprAsKeyword(expr); name != "" {
+		return name, true
+	}
+
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || val.Type() != cty.String {
+		return "", false
+	}
+	name := val.AsString()
+	if !hclsyntax.ValidIdentifier(name) {
+		return "", false
+	}
+
+	return name, true
+}
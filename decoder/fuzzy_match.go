@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl-lang/lang"
+)
+
+// matchPrefix reports whether name matches the prefix typed so far.
+//
+// The prefix must be a literal prefix of the name, unless fuzzy
+// matching is enabled, in which case it is enough for the prefix
+// to be a subsequence of the name (e.g. rsrc matches resource).
+// The returned penalty then describes how far the match is
+// from a literal prefix, where 0 is a literal prefix.
+//
+// Case is ignored if case-insensitive matching is enabled.
+func (d *PathDecoder) matchPrefix(name, prefix string) (int, bool) {
+	if d.decoderCtx.Completion.CaseInsensitiveMatching {
+		name, prefix = strings.ToLower(name), strings.ToLower(prefix)
+	}
+
+	if !d.decoderCtx.Completion.EnableFuzzyMatching {
+		return 0, strings.HasPrefix(name, prefix)
+	}
+
+	return fuzzyMatch(name, prefix)
+}
+
+// isPrefixTooShort reports whether the prefix typed so far
+// is too short for automatically triggered completion
+func (d *PathDecoder) isPrefixTooShort(ctx context.Context, prefix string) bool {
+	if isManualCompletion(ctx) {
+		return false
+	}
+	return utf8.RuneCountInString(prefix) < d.decoderCtx.Completion.MinPrefixForAutoComplete
+}
+
+// fuzzyMatch matches the pattern as subsequence of the name,
+// picking the leftmost occurrence of each character. The penalty
+// is the number of characters skipped before and within the match.
+func fuzzyMatch(name, pattern string) (int, bool) {
+	penalty := 0
+	nameIdx := 0
+	for _, r := range pattern {
+		idx := strings.IndexRune(name[nameIdx:], r)
+		if idx < 0 {
+			return 0, false
+		}
+		penalty += utf8.RuneCountInString(name[nameIdx : nameIdx+idx])
+		nameIdx += idx + len(string(r))
+	}
+
+	return penalty, true
+}
+
+// withMatchSortText ranks the candidate by match quality,
+// such that closer fuzzy matches are listed first.
+// Candidates are ranked only when fuzzy matching is enabled
+// and there is a prefix to match, i.e. the ordering stays intact otherwise.
+func (d *PathDecoder) withMatchSortText(candidate lang.Candidate, penalty int, prefix string) lang.Candidate {
+	if !d.decoderCtx.Completion.EnableFuzzyMatching || len(prefix) == 0 {
+		return candidate
+	}
+
+	sortKey := candidate.SortText
+	if sortKey == "" {
+		sortKey = candidate.Label
+	}
+	candidate.SortText = fmt.Sprintf("%05d %s", penalty, sortKey)
+
+	return candidate
+}
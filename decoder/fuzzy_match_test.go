@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	testCases := []struct {
+		name            string
+		pattern         string
+		expectedPenalty int
+		expectedMatch   bool
+	}{
+		{"resource", "", 0, true},
+		{"resource", "res", 0, true},
+		{"resource", "rsrc", 3, true},
+		{"resource", "src", 4, true},
+		{"resource", "rsx", 0, false},
+		{"resource", "cr", 0, false},
+		{"naïve", "nv", 2, true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s-%s", i, tc.name, tc.pattern), func(t *testing.T) {
+			penalty, ok := fuzzyMatch(tc.name, tc.pattern)
+			if ok != tc.expectedMatch {
+				t.Fatalf("expected match: %t, got: %t", tc.expectedMatch, ok)
+			}
+			if penalty != tc.expectedPenalty {
+				t.Fatalf("expected penalty: %d, got: %d", tc.expectedPenalty, penalty)
+			}
+		})
+	}
+}
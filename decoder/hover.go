@@ -48,16 +48,22 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 	filename := body.Range().Filename
 
 	for name, attr := range body.Attributes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if attr.Range().ContainsPos(pos) {
 			var aSchema *schema.AttributeSchema
 			if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
 				ctx = schema.WithActiveSelfRefs(ctx)
 			}
+			ctx = schema.WithBodyExtensions(ctx, bodySchema.Extensions)
 
 			if bodySchema.Extensions != nil && bodySchema.Extensions.Count && name == "count" {
 				aSchema = schemahelper.CountAttributeSchema()
 			} else if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && name == "for_each" {
 				aSchema = schemahelper.ForEachAttributeSchema()
+			} else if bodySchema.Extensions != nil && bodySchema.Extensions.DependsOn && name == "depends_on" {
+				aSchema = schemahelper.DependsOnAttributeSchema(bodySchema.Extensions.DependsOnScopeIds)
 			} else {
 				var ok bool
 				aSchema, ok = bodySchema.Attributes[attr.Name]
@@ -74,19 +80,32 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 			}
 
 			if attr.NameRange.ContainsPos(pos) {
+				content := hoverContentForAttribute(name, aSchema)
+				content.Value += hoverContentForMetaArgument(ctx, name)
 				return &lang.HoverData{
-					Content: hoverContentForAttribute(name, aSchema),
+					Content: content,
 					Range:   attr.Range(),
 				}, nil
 			}
 
 			if attr.Expr.Range().ContainsPos(pos) {
-				return d.newExpression(attr.Expr, aSchema.Constraint).HoverAtPos(ctx, pos), nil
+				hoverData := d.newExpression(attr.Expr, aSchema.Constraint).HoverAtPos(ctx, pos)
+				if _, ok := aSchema.Constraint.(schema.LiteralType); ok && hoverData != nil &&
+					hoverData.Range == attr.Expr.Range() {
+					// literal values are also described by the attribute itself
+					if description := descriptionForAttribute(aSchema); description.Value != "" {
+						hoverData.Content = lang.Markdown(hoverData.Content.Value + "\n\n" + description.Value)
+					}
+				}
+				return hoverData, nil
 			}
 		}
 	}
 
 	for _, block := range body.Blocks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if block.Range().ContainsPos(pos) {
 			blockSchema, ok := bodySchema.Blocks[block.Type]
 			if !ok {
@@ -99,7 +118,7 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 
 			if block.TypeRange.ContainsPos(pos) {
 				return &lang.HoverData{
-					Content: d.hoverContentForBlock(block.Type, blockSchema),
+					Content: d.hoverContentForBlock(block, blockSchema),
 					Range:   block.TypeRange,
 				}, nil
 			}
@@ -163,6 +182,9 @@ func (d *PathDecoder) hoverContentForLabel(i int, block *hclsyntax.Block, bSchem
 				content += "\n\n" + labelSchema.Description.Value
 			}
 
+			// describe attributes the label unlocks
+			content += hoverContentForAttributes(bs.Attributes)
+
 			if bs.HoverURL != "" {
 				u, err := d.docsURL(bs.HoverURL, "documentHover")
 				if err == nil {
@@ -187,12 +209,25 @@ func (d *PathDecoder) hoverContentForLabel(i int, block *hclsyntax.Block, bSchem
 	return lang.Markdown(content)
 }
 
-func (d *PathDecoder) hoverContentForBlock(bType string, schema *schema.BlockSchema) lang.MarkupContent {
+func (d *PathDecoder) hoverContentForBlock(block *hclsyntax.Block, schema *schema.BlockSchema) lang.MarkupContent {
+	bType := block.Type
 	value := fmt.Sprintf("**%s** _%s_", bType, detailForBlock(schema))
-	if schema.Description.Value != "" {
-		value += fmt.Sprintf("\n\n%s", schema.Description.Value)
+	if description := descriptionForBlock(schema); description.Value != "" {
+		value += fmt.Sprintf("\n\n%s", description.Value)
 	}
 
+	if len(schema.Labels) > 0 {
+		labelNames := make([]string, len(schema.Labels))
+		for i, label := range schema.Labels {
+			labelNames[i] = fmt.Sprintf("`%s`", label.Name)
+		}
+		value += fmt.Sprintf("\n\nLabels: %s", strings.Join(labelNames, ", "))
+	}
+
+	// reflect the dependent body if labels are already declared
+	bodySchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), schema)
+	value += hoverContentForAttributes(bodySchema.Attributes)
+
 	if schema.Body != nil && schema.Body.HoverURL != "" {
 		u, err := d.docsURL(schema.Body.HoverURL, "documentHover")
 		if err == nil {
@@ -207,6 +242,35 @@ func (d *PathDecoder) hoverContentForBlock(bType string, schema *schema.BlockSch
 	}
 }
 
+// hoverContentForMetaArgument describes symbols introduced
+// by the count or for_each meta-argument, where active
+func hoverContentForMetaArgument(ctx context.Context, name string) string {
+	if name == "count" && schema.ActiveCountFromContext(ctx) {
+		return "\n\nIntroduces:" +
+			"\n- `count.index` _number_ - the distinct index number (starting with 0) of the current instance"
+	}
+	if name == "for_each" && schema.ActiveForEachFromContext(ctx) {
+		return "\n\nIntroduces:" +
+			"\n- `each.key` _string_ - the map key (or set member) of the current instance" +
+			"\n- `each.value` _dynamic_ - the map value of the current instance (same as `each.key` for sets)"
+	}
+	return ""
+}
+
+// hoverContentForAttributes returns a list of the given attributes
+// with their details, or an empty string if there are none
+func hoverContentForAttributes(attributes map[string]*schema.AttributeSchema) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	content := "\n\nAttributes:"
+	for _, name := range sortedAttributeNames(attributes) {
+		content += fmt.Sprintf("\n- `%s` _%s_", name, detailForAttribute(attributes[name]))
+	}
+	return content
+}
+
 func hoverContentForReferenceTarget(ctx context.Context, ref reference.Target, pos hcl.Pos) (string, error) {
 	content := fmt.Sprintf("`%s`", ref.Address(ctx, pos))
 
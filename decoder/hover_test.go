@@ -116,7 +116,7 @@ func TestDecoder_HoverAtPos_nilBodySchema(t *testing.T) {
 				Byte:   1,
 			},
 			&lang.HoverData{
-				Content: lang.Markdown("**resource** _Block_"),
+				Content: lang.Markdown("**resource** _Block_\n\nLabels: `type`, `name`"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start: hcl.Pos{
@@ -169,7 +169,11 @@ func TestDecoder_HoverAtPos_nilBodySchema(t *testing.T) {
 				Byte:   12,
 			},
 			&lang.HoverData{
-				Content: lang.Markdown("`label1` type"),
+				Content: lang.Markdown("`label1` type\n\n" +
+					"Attributes:\n" +
+					"- `one` _string_\n" +
+					"- `three` _bool_\n" +
+					"- `two` _number_"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start: hcl.Pos{
@@ -448,8 +452,9 @@ func TestDecoder_HoverAtPos_rightHandSide(t *testing.T) {
 		Labels: resourceLabelSchema,
 		Body: &schema.BodySchema{
 			Attributes: map[string]*schema.AttributeSchema{
-				"num_attr": {Constraint: schema.LiteralType{Type: cty.Number}},
-				"str_attr": {Constraint: schema.LiteralType{Type: cty.String}, Description: lang.PlainText("Special attribute")},
+				"num_attr":  {Constraint: schema.LiteralType{Type: cty.Number}},
+				"str_attr":  {Constraint: schema.LiteralType{Type: cty.String}, Description: lang.PlainText("Special attribute")},
+				"bool_attr": {Constraint: schema.LiteralType{Type: cty.Bool}, Description: lang.Markdown("Flag attribute")},
 			},
 		},
 	}
@@ -460,6 +465,8 @@ func TestDecoder_HoverAtPos_rightHandSide(t *testing.T) {
 	}
 	testConfig := []byte(`myblock "foo" {
   str_attr = "test"
+  num_attr = 3
+  bool_attr = true
 }
 `)
 
@@ -472,25 +479,60 @@ func TestDecoder_HoverAtPos_rightHandSide(t *testing.T) {
 		},
 	})
 
-	ctx := context.Background()
-	data, err := d.HoverAtPos(ctx, "test.tf", hcl.Pos{
-		Line:   2,
-		Column: 17,
-		Byte:   32,
-	})
-	if err != nil {
-		t.Fatal(err)
-	}
-	expectedData := &lang.HoverData{
-		Content: lang.Markdown("_string_"),
-		Range: hcl.Range{
-			Filename: "test.tf",
-			Start:    hcl.Pos{Line: 2, Column: 14, Byte: 29},
-			End:      hcl.Pos{Line: 2, Column: 20, Byte: 35},
+	testCases := []struct {
+		name         string
+		pos          hcl.Pos
+		expectedData *lang.HoverData
+	}{
+		{
+			"string with description",
+			hcl.Pos{Line: 2, Column: 17, Byte: 32},
+			&lang.HoverData{
+				Content: lang.Markdown("_string_\n\nSpecial attribute"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 14, Byte: 29},
+					End:      hcl.Pos{Line: 2, Column: 20, Byte: 35},
+				},
+			},
+		},
+		{
+			"number without description",
+			hcl.Pos{Line: 3, Column: 14, Byte: 49},
+			&lang.HoverData{
+				Content: lang.Markdown("_number_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 3, Column: 14, Byte: 49},
+					End:      hcl.Pos{Line: 3, Column: 15, Byte: 50},
+				},
+			},
+		},
+		{
+			"bool with description",
+			hcl.Pos{Line: 4, Column: 16, Byte: 66},
+			&lang.HoverData{
+				Content: lang.Markdown("_bool_\n\nFlag attribute"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 4, Column: 15, Byte: 65},
+					End:      hcl.Pos{Line: 4, Column: 19, Byte: 69},
+				},
+			},
 		},
 	}
-	if diff := cmp.Diff(expectedData, data, ctydebug.CmpOptions); diff != "" {
-		t.Fatalf("hover data mismatch: %s", diff)
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			ctx := context.Background()
+			data, err := d.HoverAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedData, data, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("hover data mismatch: %s", diff)
+			}
+		})
 	}
 }
 
@@ -508,6 +550,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 				"str_attr": {
 					Constraint:  schema.LiteralType{Type: cty.String},
 					IsOptional:  true,
+					MaxLength:   63,
 					Description: lang.PlainText("Special attribute"),
 				},
 				"bool_attr": {
@@ -577,7 +620,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 			"optional attribute name",
 			hcl.Pos{Line: 2, Column: 6, Byte: 32},
 			&lang.HoverData{
-				Content: lang.Markdown("**str_attr** _optional, string_\n\nSpecial attribute"),
+				Content: lang.Markdown("**str_attr** _optional, string, max length 63_\n\nSpecial attribute"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 29},
@@ -601,7 +644,13 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 			"block type",
 			hcl.Pos{Line: 1, Column: 3, Byte: 2},
 			&lang.HoverData{
-				Content: lang.Markdown("**myblock** _Block_\n\nMy special block"),
+				Content: lang.Markdown("**myblock** _Block_\n\nMy special block\n\n" +
+					"Labels: `type`, `name`\n\n" +
+					"Attributes:\n" +
+					"- `bool_attr` _sensitive, bool_\n" +
+					"- `num_attr` _number_\n" +
+					"- `object_attr` _object_\n" +
+					"- `str_attr` _optional, string, max length 63_"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
@@ -661,6 +710,183 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 	}
 }
 
+func TestDecoder_HoverAtPos_blockTypeDependentBody(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Description: lang.PlainText("Managed resource"),
+				Labels: []*schema.LabelSchema{
+					{Name: "type", IsDepKey: true},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "aws_instance"},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"ami": {Constraint: schema.LiteralType{Type: cty.String}, IsRequired: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName        string
+		cfg             string
+		expectedContent lang.MarkupContent
+	}{
+		{
+			"known dependent body",
+			`resource "aws_instance" "foo" {
+}
+`,
+			lang.Markdown("**resource** _Block_\n\nManaged resource\n\n" +
+				"Labels: `type`, `name`\n\n" +
+				"Attributes:\n" +
+				"- `ami` _required, string_\n" +
+				"- `count` _optional, number_"),
+		},
+		{
+			"unknown dependent body",
+			`resource "unknown" "foo" {
+}
+`,
+			lang.Markdown("**resource** _Block_\n\nManaged resource\n\n" +
+				"Labels: `type`, `name`\n\n" +
+				"Attributes:\n" +
+				"- `count` _optional, number_"),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			data, err := d.HoverAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 3, Byte: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedData := &lang.HoverData{
+				Content: tc.expectedContent,
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+				},
+			}
+			if diff := cmp.Diff(expectedData, data); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_HoverAtPos_dependentLabel(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:        "type",
+						IsDepKey:    true,
+						Description: lang.PlainText("Resource type"),
+					},
+					{Name: "name"},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "azurerm_subnet"},
+						},
+					}): {
+						Detail:      "azurerm",
+						Description: lang.PlainText("Manages a subnet"),
+						Attributes: map[string]*schema.AttributeSchema{
+							"name":                 {Constraint: schema.LiteralType{Type: cty.String}, IsRequired: true},
+							"virtual_network_name": {Constraint: schema.LiteralType{Type: cty.String}, IsRequired: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName     string
+		cfg          string
+		expectedData *lang.HoverData
+	}{
+		{
+			"matching dependent body",
+			`resource "azurerm_subnet" "foo" {
+}
+`,
+			&lang.HoverData{
+				Content: lang.Markdown("`azurerm_subnet` azurerm\n\nManages a subnet\n\n" +
+					"Attributes:\n" +
+					"- `name` _required, string_\n" +
+					"- `virtual_network_name` _required, string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					End:      hcl.Pos{Line: 1, Column: 26, Byte: 25},
+				},
+			},
+		},
+		{
+			"no matching dependent body",
+			`resource "unknown" "foo" {
+}
+`,
+			&lang.HoverData{
+				Content: lang.Markdown("\"unknown\" (type)\n\nResource type"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			data, err := d.HoverAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 12, Byte: 11})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedData, data); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_HoverAtPos_nil_expr(t *testing.T) {
 	// provider:: is not a traversal expression, so hcl will return a ExprSyntaxError which needs to be handled
 	f, _ := hclsyntax.ParseConfig([]byte(`attr = provider::`), "test.tf", hcl.InitialPos)
@@ -821,6 +1047,11 @@ Sushi, the Rolls-Rice of Japanese cuisine
 
 My food block
 
+Labels: ` + "`type`, `name`" + `
+
+Attributes:
+- ` + "`any_attr`" + ` _number_
+
 [` + "`myblock`" + ` on en.wikipedia.org](https://en.wikipedia.org/wiki/Food)`,
 					Kind: lang.MarkdownKind,
 				},
@@ -965,6 +1196,65 @@ func TestDecoder_HoverAtPos_typeDeclaration(t *testing.T) {
 	}
 }
 
+func TestDecoder_HoverAtPos_countWithoutExtension(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"count": {
+				Constraint:  schema.LiteralType{Type: cty.Number},
+				IsOptional:  true,
+				Description: lang.PlainText("Plain attribute"),
+			},
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte("count = 1\n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	data, err := d.HoverAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 2, Byte: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// symbols of the meta-argument are only described where it is active
+	expectedContent := lang.Markdown("**count** _optional, number_\n\nPlain attribute")
+	if diff := cmp.Diff(expectedContent, data.Content); diff != "" {
+		t.Fatalf("unexpected hover content: %s", diff)
+	}
+}
+
+func TestDecoder_HoverAtPos_defaultValue(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"region": {
+				Constraint:   schema.LiteralType{Type: cty.String},
+				IsOptional:   true,
+				DefaultValue: schema.DefaultValue{Value: cty.StringVal("us-east-1")},
+			},
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte("region = \"eu-west-1\"\n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	data, err := d.HoverAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 2, Byte: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedContent := lang.Markdown("**region** _optional, string, default: \"us-east-1\"_")
+	if diff := cmp.Diff(expectedContent, data.Content); diff != "" {
+		t.Fatalf("unexpected hover content: %s", diff)
+	}
+}
+
 func TestDecoder_HoverAtPos_extensions_count(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -1000,7 +1290,8 @@ func TestDecoder_HoverAtPos_extensions_count(t *testing.T) {
 `,
 			hcl.Pos{Line: 2, Column: 5, Byte: 24},
 			&lang.HoverData{
-				Content: lang.Markdown("**count** _optional, number_\n\nTotal number of instances of this block.\n\n**Note**: A given block cannot use both `count` and `for_each`."),
+				Content: lang.Markdown("**count** _optional, number_\n\nTotal number of instances of this block.\n\n**Note**: A given block cannot use both `count` and `for_each`." +
+					"\n\nIntroduces:\n- `count.index` _number_ - the distinct index number (starting with 0) of the current instance"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
@@ -1064,7 +1355,7 @@ func TestDecoder_HoverAtPos_extensions_count(t *testing.T) {
 `,
 			hcl.Pos{Line: 3, Column: 15, Byte: 48},
 			&lang.HoverData{
-				Content: lang.Markdown("`count.index`\n_number_\n\nThe distinct index number (starting with 0) corresponding to the instance"),
+				Content: lang.Markdown("`count.index`\n_number_\n\nThe distinct index number (starting with 0) corresponding to the instance\n\nOne of:\n- **_reference_ (number)**\n- _number_"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 3, Column: 11, Byte: 44},
@@ -1176,7 +1467,10 @@ func TestDecoder_HoverAtPos_extension_for_each(t *testing.T) {
 				Content: lang.MarkupContent{
 					Value: "**for_each** _optional, map of any single type or set of string or object_\n\n" +
 						"A meta-argument that accepts a map or a set of strings, and creates an instance for each item in that map or set.\n\n" +
-						"**Note**: A given block cannot use both `count` and `for_each`.",
+						"**Note**: A given block cannot use both `count` and `for_each`.\n\n" +
+						"Introduces:\n" +
+						"- `each.key` _string_ - the map key (or set member) of the current instance\n" +
+						"- `each.value` _dynamic_ - the map value of the current instance (same as `each.key` for sets)",
 					Kind: lang.MarkdownKind,
 				},
 				Range: hcl.Range{
@@ -1467,7 +1761,12 @@ func TestDecoder_HoverAtPos_extensions_dynamic(t *testing.T) {
 			&lang.HoverData{
 				Content: lang.MarkupContent{
 					Value: "**dynamic** _Block_\n\n" +
-						"A dynamic block to produce blocks dynamically by iterating over a given complex value",
+						"A dynamic block to produce blocks dynamically by iterating over a given complex value\n\n" +
+						"Labels: `name`\n\n" +
+						"Attributes:\n" +
+						"- `for_each` _required, map of any single type or list of any single type or set of string_\n" +
+						"- `iterator` _optional, string_\n" +
+						"- `labels` _optional, list of string_",
 					Kind: lang.MarkdownKind,
 				},
 				Range: hcl.Range{
@@ -1599,7 +1898,12 @@ func TestDecoder_HoverAtPos_extensions_dynamic(t *testing.T) {
 			&lang.HoverData{
 				Content: lang.MarkupContent{
 					Value: "**dynamic** _Block_\n\n" +
-						"A dynamic block to produce blocks dynamically by iterating over a given complex value",
+						"A dynamic block to produce blocks dynamically by iterating over a given complex value\n\n" +
+						"Labels: `name`\n\n" +
+						"Attributes:\n" +
+						"- `for_each` _required, map of any single type or list of any single type or set of string_\n" +
+						"- `iterator` _optional, string_\n" +
+						"- `labels` _optional, list of string_",
 					Kind: lang.MarkdownKind,
 				},
 				Range: hcl.Range{
@@ -1705,7 +2009,7 @@ variable "name" {
 `,
 			hcl.Pos{Line: 3, Column: 19, Byte: 59},
 			&lang.HoverData{
-				Content: lang.Markdown("`var.name`\n_dynamic_"),
+				Content: lang.Markdown("`var.name`\n_dynamic_\n\nOne of:\n- _map of any single type_\n- _set of string_\n- _object_"),
 				Range: hcl.Range{
 					Filename: "test.tf",
 					Start:    hcl.Pos{Line: 3, Column: 14, Byte: 54},
@@ -1824,3 +2128,93 @@ variable "name" {
 		})
 	}
 }
+
+func TestDecoder_HoverAtPos_lazyDescription(t *testing.T) {
+	attrCalls, blockCalls := 0, 0
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+				},
+				DescriptionFunc: func() lang.MarkupContent {
+					blockCalls++
+					return lang.Markdown("Lazy block")
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+							IsOptional: true,
+							DescriptionFunc: func() lang.MarkupContent {
+								attrCalls++
+								return lang.PlainText("Lazy attribute")
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig([]byte(`resource "label1" {
+  count = 42
+}
+`), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+	_, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attrCalls != 0 || blockCalls != 0 {
+		t.Fatalf("expected no description calls before hover, got %d (attribute), %d (block)",
+			attrCalls, blockCalls)
+	}
+
+	data, err := d.HoverAtPos(ctx, "test.tf", hcl.Pos{
+		Line:   2,
+		Column: 4,
+		Byte:   23,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedContent := lang.Markdown("**count** _optional, number_\n\nLazy attribute")
+	if diff := cmp.Diff(expectedContent, data.Content); diff != "" {
+		t.Fatalf("unexpected attribute hover content: %s", diff)
+	}
+	if attrCalls != 1 {
+		t.Fatalf("expected attribute description to be resolved once, got %d", attrCalls)
+	}
+
+	data, err = d.HoverAtPos(ctx, "test.tf", hcl.Pos{
+		Line:   1,
+		Column: 3,
+		Byte:   2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedContent = lang.Markdown("**resource** _Block_\n\nLazy block\n\nLabels: `type`\n\nAttributes:\n- `count` _optional, number_")
+	if diff := cmp.Diff(expectedContent, data.Content); diff != "" {
+		t.Fatalf("unexpected block hover content: %s", diff)
+	}
+	if blockCalls != 1 {
+		t.Fatalf("expected block description to be resolved once, got %d", blockCalls)
+	}
+}
@@ -35,3 +35,17 @@ func ForEachAttributeSchema() *schema.AttributeSchema {
 			"**Note**: A given block cannot use both `count` and `for_each`."),
 	}
 }
+
+func DependsOnAttributeSchema(scopeIds []lang.ScopeId) *schema.AttributeSchema {
+	return &schema.AttributeSchema{
+		IsOptional: true,
+		Constraint: schema.List{
+			Elem: schema.Reference{
+				OfScopeIds: scopeIds,
+				Name:       "block address",
+			},
+		},
+		Description: lang.Markdown("A meta-argument that declares explicit dependencies on other blocks, " +
+			"which cannot be inferred from references in the configuration."),
+	}
+}
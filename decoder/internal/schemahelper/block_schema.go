@@ -4,11 +4,26 @@
 package schemahelper
 
 import (
+	"context"
+
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 )
 
 func MergeBlockBodySchemas(block *hcl.Block, blockSchema *schema.BlockSchema) (*schema.BodySchema, LookupResult) {
+	depSchema, _, result := NewBlockSchema(blockSchema).DependentBodySchema(block)
+	return mergeBlockBodySchemas(blockSchema, depSchema, result)
+}
+
+// MergeBlockBodySchemasWithCache is like MergeBlockBodySchemas
+// but looks up the dependent body via the cache attached
+// to the context (if any), see WithDependentBodyCache.
+func MergeBlockBodySchemasWithCache(ctx context.Context, block *hcl.Block, blockSchema *schema.BlockSchema) (*schema.BodySchema, LookupResult) {
+	depSchema, _, result := CachedDependentBodySchema(ctx, block, blockSchema)
+	return mergeBlockBodySchemas(blockSchema, depSchema, result)
+}
+
+func mergeBlockBodySchemas(blockSchema *schema.BlockSchema, depSchema *schema.BodySchema, result LookupResult) (*schema.BodySchema, LookupResult) {
 	mergedSchema := &schema.BodySchema{}
 	if blockSchema.Body != nil {
 		mergedSchema = blockSchema.Body.Copy()
@@ -26,7 +41,6 @@ func MergeBlockBodySchemas(block *hcl.Block, blockSchema *schema.BlockSchema) (*
 		mergedSchema.ImpliedOrigins = make([]schema.ImpliedOrigin, 0)
 	}
 
-	depSchema, _, result := NewBlockSchema(blockSchema).DependentBodySchema(block)
 	if result == LookupSuccessful || result == LookupPartiallySuccessful {
 		for name, attr := range depSchema.Attributes {
 			mergedSchema.Attributes[name] = attr
@@ -33,30 +33,29 @@ func NewBlockSchema(bs *schema.BlockSchema) blockSchema {
 // DependentBodySchema finds relevant BodySchema based on dependency keys
 // such as a label or an attribute (or combination of both).
 func (bs blockSchema) DependentBodySchema(block *hcl.Block) (*schema.BodySchema, schema.DependencyKeys, LookupResult) {
-	result := LookupFailed
-
 	dks := dependencyKeysFromBlock(block, bs)
 	b, err := dks.MarshalJSON()
 	if err != nil {
-		return nil, schema.DependencyKeys{}, result
+		return nil, schema.DependencyKeys{}, LookupFailed
 	}
 
+	return bs.dependentBodySchemaForKeys(block, dks, schema.SchemaKey(string(b)))
+}
+
+// dependentBodySchemaForKeys is like DependentBodySchema
+// but uses dependency keys already resolved for the block
+func (bs blockSchema) dependentBodySchemaForKeys(block *hcl.Block, dks schema.DependencyKeys, key schema.SchemaKey) (*schema.BodySchema, schema.DependencyKeys, LookupResult) {
+	result := LookupFailed
+
 	if len(dks.Labels) == 0 && len(dks.Attributes) == 0 {
 		return bs.Body, schema.DependencyKeys{}, NoDependentKeys
 	}
 
-	key := schema.SchemaKey(string(b))
 	depBodySchema, ok := bs.DependentBody[key]
 	if ok {
 		result = LookupSuccessful
-		hasDepKeys := false
-		for _, attr := range depBodySchema.Attributes {
-			if attr.IsDepKey {
-				hasDepKeys = true
-			}
-		}
 
-		if hasDepKeys && !bs.seenNestedDepKeys {
+		if hasDepKeyAttributes(depBodySchema) && !bs.seenNestedDepKeys {
 			mergedBlockSchema := NewBlockSchema(bs.Copy())
 			mergedBlockSchema.seenNestedDepKeys = true
 			mergedBlockSchema.Body = depBodySchema
@@ -73,6 +72,52 @@ func (bs blockSchema) DependentBodySchema(block *hcl.Block) (*schema.BodySchema,
 	return depBodySchema, dks, result
 }
 
+// hasDepKeyAttributes reports whether the body declares any
+// attributes which are dependency keys themselves
+func hasDepKeyAttributes(bodySchema *schema.BodySchema) bool {
+	for _, attr := range bodySchema.Attributes {
+		if attr.IsDepKey {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelDependentsBefore returns dependency keys for dependency-key
+// labels which precede the label at the given index and are
+// already declared (non-empty) in the block.
+func LabelDependentsBefore(idx int, labels []string, labelSchemas []*schema.LabelSchema) []schema.LabelDependent {
+	lds := make([]schema.LabelDependent, 0)
+	for i, labelSchema := range labelSchemas {
+		if i >= idx || i >= len(labels) {
+			break
+		}
+		if !labelSchema.IsDepKey || labels[i] == "" {
+			continue
+		}
+		lds = append(lds, schema.LabelDependent{
+			Index: i,
+			Value: labels[i],
+		})
+	}
+	return lds
+}
+
+// MatchesLabelPrefix reports whether the given dependency keys
+// are compatible with already chosen label keys, i.e. whether
+// none of the keys' labels conflicts with a chosen label
+// at the same index.
+func MatchesLabelPrefix(dks schema.DependencyKeys, prefix []schema.LabelDependent) bool {
+	for _, chosen := range prefix {
+		for _, label := range dks.Labels {
+			if label.Index == chosen.Index && label.Value != chosen.Value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func dependencyKeysFromBlock(block *hcl.Block, blockSchema blockSchema) schema.DependencyKeys {
 	dk := schema.DependencyKeys{
 		Labels:     []schema.LabelDependent{},
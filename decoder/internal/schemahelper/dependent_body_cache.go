@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemahelper
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+)
+
+type dependentBodyCacheCtxKey struct{}
+
+// dependentBodyCacheKey identifies a dependent body lookup,
+// such that any blocks of the same schema with the same
+// values of dependency key labels share it.
+type dependentBodyCacheKey struct {
+	blockSchema *schema.BlockSchema
+	labels      string
+}
+
+type dependentBodyCacheEntry struct {
+	bodySchema *schema.BodySchema
+	dks        schema.DependencyKeys
+	result     LookupResult
+}
+
+type dependentBodyCache struct {
+	mu      sync.Mutex
+	entries map[dependentBodyCacheKey]dependentBodyCacheEntry
+}
+
+// WithDependentBodyCache attaches a cache of dependent body lookups
+// to the context. The cache is meant to live only as long as
+// a single request which looks up many blocks (such as validation).
+func WithDependentBodyCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dependentBodyCacheCtxKey{}, &dependentBodyCache{
+		entries: make(map[dependentBodyCacheKey]dependentBodyCacheEntry, 0),
+	})
+}
+
+func dependentBodyCacheFromContext(ctx context.Context) (*dependentBodyCache, bool) {
+	cache, ok := ctx.Value(dependentBodyCacheCtxKey{}).(*dependentBodyCache)
+	return cache, ok
+}
+
+// CachedDependentBodySchema is like DependentBodySchema
+// but memoizes the lookup in the cache attached to the context
+// via WithDependentBodyCache, if any.
+//
+// Only lookups determined by labels alone are cached, which
+// allows cache hits to skip decoding the block body and
+// encoding the dependency keys.
+func CachedDependentBodySchema(ctx context.Context, block *hcl.Block, bs *schema.BlockSchema) (*schema.BodySchema, schema.DependencyKeys, LookupResult) {
+	cache, ok := dependentBodyCacheFromContext(ctx)
+	if !ok {
+		return NewBlockSchema(bs).DependentBodySchema(block)
+	}
+
+	labels, ok := dependencyKeyLabels(block, bs)
+	if !ok {
+		return NewBlockSchema(bs).DependentBodySchema(block)
+	}
+
+	key := dependentBodyCacheKey{
+		blockSchema: bs,
+		labels:      labels,
+	}
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[key]
+	cache.mu.Unlock()
+	if ok {
+		return entry.bodySchema, entry.dks, entry.result
+	}
+
+	dks := dependencyKeysFromBlock(block, NewBlockSchema(bs))
+	b, err := dks.MarshalJSON()
+	if err != nil {
+		return nil, schema.DependencyKeys{}, LookupFailed
+	}
+	depKeys := schema.SchemaKey(string(b))
+
+	bodySchema, dks, result := NewBlockSchema(bs).dependentBodySchemaForKeys(block, dks, depKeys)
+
+	// dependent bodies with dependency keys of their own are looked up
+	// based on further attributes, which the labels don't reflect
+	if depBody, ok := bs.DependentBody[depKeys]; ok && hasDepKeyAttributes(depBody) {
+		return bodySchema, dks, result
+	}
+
+	cache.mu.Lock()
+	cache.entries[key] = dependentBodyCacheEntry{
+		bodySchema: bodySchema,
+		dks:        dks,
+		result:     result,
+	}
+	cache.mu.Unlock()
+
+	return bodySchema, dks, result
+}
+
+// dependencyKeyLabels returns values of the dependency key labels
+// of the block, as long as these fully determine the dependency keys,
+// i.e. the body has no dependency key attributes
+func dependencyKeyLabels(block *hcl.Block, bs *schema.BlockSchema) (string, bool) {
+	if bs.Body != nil && hasDepKeyAttributes(bs.Body) {
+		return "", false
+	}
+
+	var labels strings.Builder
+	for i, labelSchema := range bs.Labels {
+		if !labelSchema.IsDepKey {
+			continue
+		}
+		if i >= len(block.Labels) {
+			// mismatching label schema
+			return "", false
+		}
+		labels.WriteString(strconv.Quote(block.Labels[i]))
+	}
+
+	return labels.String(), true
+}
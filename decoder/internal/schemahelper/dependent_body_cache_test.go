@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemahelper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestCachedDependentBodySchema(t *testing.T) {
+	newBlock := func(filename string, labels ...string) *hcl.Block {
+		return &hcl.Block{
+			Type:   "resource",
+			Labels: labels,
+			Body:   hcl.EmptyBody(),
+			DefRange: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 31, Byte: 30},
+			},
+		}
+	}
+	depKey := schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: "theircloud"},
+		},
+	})
+	firstBody := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"foo": {Constraint: schema.LiteralType{Type: cty.String}},
+		},
+	}
+	secondBody := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"bar": {Constraint: schema.LiteralType{Type: cty.Number}},
+		},
+	}
+	bSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "type", IsDepKey: true},
+			{Name: "name"},
+		},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			depKey: firstBody,
+		},
+	}
+
+	ctx := WithDependentBodyCache(context.Background())
+
+	bodySchema, _, result := CachedDependentBodySchema(ctx, newBlock("first.tf", "theircloud", "blah"), bSchema)
+	if result != LookupSuccessful || bodySchema != firstBody {
+		t.Fatalf("unexpected lookup result: %#v (%d)", bodySchema, result)
+	}
+
+	// swapping the dependent body reveals whether lookups are cached
+	bSchema.DependentBody[depKey] = secondBody
+
+	bodySchema, _, _ = CachedDependentBodySchema(ctx, newBlock("first.tf", "theircloud", "blah"), bSchema)
+	if bodySchema != firstBody {
+		t.Fatalf("expected cached body schema, given: %#v", bodySchema)
+	}
+
+	// blocks with the same dependency keys share the lookup
+	bodySchema, _, _ = CachedDependentBodySchema(ctx, newBlock("second.tf", "theircloud", "other"), bSchema)
+	if bodySchema != firstBody {
+		t.Fatalf("expected block with the same dependency keys to share cached body schema, given: %#v", bodySchema)
+	}
+
+	otherDepKey := schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: "mycloud"},
+		},
+	})
+	bSchema.DependentBody[otherDepKey] = secondBody
+	bodySchema, _, _ = CachedDependentBodySchema(ctx, newBlock("first.tf", "mycloud", "blah"), bSchema)
+	if bodySchema != secondBody {
+		t.Fatalf("expected block with different dependency keys not to be cached, given: %#v", bodySchema)
+	}
+
+	bodySchema, _, _ = CachedDependentBodySchema(WithDependentBodyCache(context.Background()), newBlock("first.tf", "theircloud", "blah"), bSchema)
+	if bodySchema != secondBody {
+		t.Fatalf("expected cache not to be shared across contexts, given: %#v", bodySchema)
+	}
+
+	bodySchema, _, _ = CachedDependentBodySchema(context.Background(), newBlock("first.tf", "theircloud", "blah"), bSchema)
+	if bodySchema != secondBody {
+		t.Fatalf("expected uncached lookup without cache in context, given: %#v", bodySchema)
+	}
+}
+
+func TestCachedDependentBodySchema_nestedDepKeys(t *testing.T) {
+	newBlock := func(kind string) *hcl.Block {
+		f, _ := hclsyntax.ParseConfig([]byte(`resource "theircloud" "blah" {
+  kind = `+kind+`
+}
+`), "test.tf", hcl.InitialPos)
+		return f.Body.(*hclsyntax.Body).Blocks[0].AsHCLBlock()
+	}
+	labelKey := schema.NewSchemaKey(schema.DependencyKeys{
+		Labels: []schema.LabelDependent{
+			{Index: 0, Value: "theircloud"},
+		},
+	})
+	newAttrKey := func(kind string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: "theircloud"},
+			},
+			Attributes: []schema.AttributeDependent{
+				{
+					Name: "kind",
+					Expr: schema.ExpressionValue{
+						Address: lang.Address{
+							lang.RootStep{Name: kind},
+						},
+					},
+				},
+			},
+		})
+	}
+	fooBody := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"foo": {Constraint: schema.LiteralType{Type: cty.String}},
+		},
+	}
+	barBody := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"bar": {Constraint: schema.LiteralType{Type: cty.String}},
+		},
+	}
+	bSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "type", IsDepKey: true},
+			{Name: "name"},
+		},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			labelKey: {
+				Attributes: map[string]*schema.AttributeSchema{
+					"kind": {
+						IsDepKey:   true,
+						Constraint: schema.Keyword{Keyword: "foo"},
+					},
+				},
+			},
+			newAttrKey("foo"): fooBody,
+			newAttrKey("bar"): barBody,
+		},
+	}
+
+	ctx := WithDependentBodyCache(context.Background())
+
+	bodySchema, _, _ := CachedDependentBodySchema(ctx, newBlock("foo"), bSchema)
+	if _, ok := bodySchema.Attributes["foo"]; !ok {
+		t.Fatalf("unexpected body schema: %#v", bodySchema)
+	}
+
+	// blocks sharing label dependency keys may still differ
+	// in nested dependency keys
+	bodySchema, _, _ = CachedDependentBodySchema(ctx, newBlock("bar"), bSchema)
+	if _, ok := bodySchema.Attributes["bar"]; !ok {
+		t.Fatalf("expected nested dependency keys to be respected, given: %#v", bodySchema)
+	}
+}
+
+func BenchmarkDependentBodySchema(b *testing.B) {
+	const typeCount = 300
+	bSchema := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{Name: "type", IsDepKey: true},
+			{Name: "name"},
+		},
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"count": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+			},
+		},
+		DependentBody: make(map[schema.SchemaKey]*schema.BodySchema, typeCount),
+	}
+	for i := 0; i < typeCount; i++ {
+		depKey := schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: fmt.Sprintf("type_%d", i)},
+			},
+		})
+		bSchema.DependentBody[depKey] = &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"foo": {Constraint: schema.LiteralType{Type: cty.String}},
+			},
+		}
+	}
+
+	// many blocks sharing a few types, as is common in configuration
+	var cfg strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&cfg, "resource \"type_%d\" \"name_%d\" {\n  count = 1\n  foo = \"bar\"\n}\n", i%10, i)
+	}
+	f, diags := hclsyntax.ParseConfig([]byte(cfg.String()), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		b.Fatal(diags)
+	}
+	blocks := make([]*hcl.Block, 0)
+	for _, block := range f.Body.(*hclsyntax.Body).Blocks {
+		blocks = append(blocks, block.AsHCLBlock())
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, block := range blocks {
+				NewBlockSchema(bSchema).DependentBodySchema(block)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			// the cache lives as long as a single request
+			ctx := WithDependentBodyCache(context.Background())
+			for _, block := range blocks {
+				CachedDependentBodySchema(ctx, block, bSchema)
+			}
+		}
+	})
+}
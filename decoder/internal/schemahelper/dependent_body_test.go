@@ -544,6 +544,69 @@ func TestBodySchema_DependentBodySchema_label_lookupUnsorted(t *testing.T) {
 	}
 }
 
+func TestBodySchema_DependentBodySchema_label_partial(t *testing.T) {
+	block := &hcl.Block{
+		Labels: []string{"complexcloud", ""},
+		Body:   hcl.EmptyBody(),
+	}
+	_, _, result := testSchemaWithLabels.DependentBodySchema(block)
+	if result != LookupFailed {
+		t.Fatal("expected not to find body schema with missing 2nd label")
+	}
+}
+
+func TestMatchesLabelPrefix(t *testing.T) {
+	labelSchemas := []*schema.LabelSchema{
+		{Name: "type", IsDepKey: true},
+		{Name: "name"},
+		{Name: "subtype", IsDepKey: true},
+	}
+	prefix := LabelDependentsBefore(2, []string{"aws", "foo", ""}, labelSchemas)
+	expectedPrefix := []schema.LabelDependent{
+		{Index: 0, Value: "aws"},
+	}
+	if diff := cmp.Diff(expectedPrefix, prefix); diff != "" {
+		t.Fatalf("unexpected prefix: %s", diff)
+	}
+
+	testCases := []struct {
+		keys          schema.DependencyKeys
+		expectedMatch bool
+	}{
+		{
+			schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "aws"},
+					{Index: 2, Value: "instance"},
+				},
+			},
+			true,
+		},
+		{
+			schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 0, Value: "google"},
+					{Index: 2, Value: "instance"},
+				},
+			},
+			false,
+		},
+		{
+			schema.DependencyKeys{
+				Labels: []schema.LabelDependent{
+					{Index: 2, Value: "instance"},
+				},
+			},
+			true,
+		},
+	}
+	for i, tc := range testCases {
+		if match := MatchesLabelPrefix(tc.keys, prefix); match != tc.expectedMatch {
+			t.Fatalf("%d: expected match %t, got %t", i, tc.expectedMatch, match)
+		}
+	}
+}
+
 func TestBodySchema_DependentBodySchema_allows_overriding(t *testing.T) {
 	testSchema := NewBlockSchema(&schema.BlockSchema{
 		Labels: []*schema.LabelSchema{
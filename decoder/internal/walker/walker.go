@@ -59,6 +59,10 @@ func Walk(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema, w
 				if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && attr.Name == "for_each" {
 					attrSchema = schemahelper.ForEachAttributeSchema()
 				}
+
+				if bodySchema.Extensions != nil && bodySchema.Extensions.DependsOn && attr.Name == "depends_on" {
+					attrSchema = schemahelper.DependsOnAttributeSchema(bodySchema.Extensions.DependsOnScopeIds)
+				}
 			}
 
 			diags = diags.Extend(Walk(bodyCtx, attr, attrSchema, w))
@@ -111,8 +115,8 @@ func Walk(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema, w
 
 		var blockBodySchema schema.Schema = nil
 		bSchema, ok := nodeSchema.(*schema.BlockSchema)
-		if ok && bSchema.Body != nil {
-			mergedSchema, result := schemahelper.MergeBlockBodySchemas(nodeType.AsHCLBlock(), bSchema)
+		if ok && (bSchema.Body != nil || len(bSchema.DependentBody) > 0) {
+			mergedSchema, result := schemahelper.MergeBlockBodySchemasWithCache(ctx, nodeType.AsHCLBlock(), bSchema)
 			if result == schemahelper.LookupFailed || result == schemahelper.LookupPartiallySuccessful {
 				blockCtx = schemacontext.WithUnknownSchema(blockCtx)
 			}
@@ -120,6 +124,8 @@ func Walk(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema, w
 		}
 
 		blockCtx = schemacontext.WithBlockNestingLevel(blockCtx, blkNestingLvl+1)
+		blockCtx = schemacontext.WithBlockDefRange(blockCtx, nodeType.DefRange())
+		blockCtx = schemacontext.WithBlockType(blockCtx, nodeType.Type)
 		diags = diags.Extend(Walk(blockCtx, nodeType.Body, blockBodySchema, w))
 
 		// TODO: case hclsyntax.Expression
@@ -10,32 +10,44 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
-func (d *PathDecoder) labelCandidatesFromDependentSchema(idx int, db map[schema.SchemaKey]*schema.BodySchema, prefixRng, editRng hcl.Range, block *hclsyntax.Block, labelSchemas []*schema.LabelSchema) (lang.Candidates, error) {
+func (d *PathDecoder) labelCandidatesFromDependentSchema(ctx context.Context, idx int, db map[schema.SchemaKey]*schema.BodySchema, prefixRng, editRng hcl.Range, block *hclsyntax.Block, labelSchemas []*schema.LabelSchema) (lang.Candidates, error) {
 	candidates := lang.NewCandidates()
-	candidates.IsComplete = true
-	count := 0
 
 	foundCandidateNames := make(map[string]bool, 0)
 
 	prefix, _ := d.bytesFromRange(prefixRng)
+	if d.isPrefixTooShort(ctx, string(prefix)) {
+		return candidates, nil
+	}
+
+	// labels are typically quoted, but may also be declared
+	// as bare identifiers, which need quoting if not valid
+	isQuoted := d.isQuotedLabelRange(editRng)
+
+	// dependency-key labels already declared before this one
+	// narrow down the keys to those compatible with them
+	chosenLabels := schemahelper.LabelDependentsBefore(idx, block.Labels, labelSchemas)
 
 	for _, schemaKey := range sortedSchemaKeys(db) {
+		if err := ctx.Err(); err != nil {
+			return lang.ZeroCandidates(), err
+		}
 		depKeys, err := decodeSchemaKey(schemaKey)
 		if err != nil {
 			// key undecodable
 			continue
 		}
-
-		if uint(count) >= d.maxCandidates {
-			// reached maximum no of candidates
-			candidates.IsComplete = false
-			break
+		if !schemahelper.MatchesLabelPrefix(depKeys, chosenLabels) {
+			continue
 		}
 
 		bodySchema := db[schemaKey]
@@ -45,7 +57,8 @@ func (d *PathDecoder) labelCandidatesFromDependentSchema(idx int, db map[schema.
 				continue
 			}
 
-			if len(prefix) > 0 && !strings.HasPrefix(label.Value, string(prefix)) {
+			penalty, ok := d.matchPrefix(label.Value, string(prefix))
+			if !ok {
 				continue
 			}
 
@@ -64,41 +77,176 @@ func (d *PathDecoder) labelCandidatesFromDependentSchema(idx int, db map[schema.
 				continue
 			}
 
+			newText := escapeQuotedLabel(label.Value)
+			if !isQuoted && !hclsyntax.ValidIdentifier(label.Value) {
+				newText = `"` + escapeQuotedLabel(label.Value) + `"`
+			}
+
 			te := lang.TextEdit{}
 			if d.PrefillRequiredFields {
-				snippet := generateRequiredFieldsSnippet(label.Value, bodySchema, labelSchemas, 2, 0)
+				snippet := generateRequiredFieldsSnippet(escapeSnippet(escapeQuotedLabel(label.Value)), bodySchema, labelSchemas, 2, 0)
 				te = lang.TextEdit{
-					NewText: label.Value,
+					NewText: newText,
 					Snippet: snippet,
 					Range:   hcl.RangeBetween(editRng, block.OpenBraceRange),
 				}
 			} else {
 				te = lang.TextEdit{
-					NewText: label.Value,
-					Snippet: label.Value,
+					NewText: newText,
+					Snippet: escapeSnippet(newText),
 					Range:   editRng,
 				}
 			}
 
-			candidates.List = append(candidates.List, lang.Candidate{
+			detail := bodySchema.Detail
+			sortText := ""
+			if d.decoderCtx.Completion.QualifyLabelCandidates && bodySchema.Namespace != "" {
+				// the label is kept bare, so that clients
+				// can still match it against the typed prefix
+				detail = qualifiedLabelDetail(bodySchema.Namespace, detail)
+				sortText = fmt.Sprintf("%s/%s", bodySchema.Namespace, label.Value)
+			}
+
+			candidate := lang.Candidate{
 				Label:        label.Value,
 				Kind:         lang.LabelCandidateKind,
 				IsDeprecated: bodySchema.IsDeprecated,
 				TextEdit:     te,
-				Detail:       bodySchema.Detail,
+				Detail:       detail,
 				Description:  bodySchema.Description,
-			})
+				SortText:     sortText,
+			}
+			candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
 
 			foundCandidateNames[label.Value] = true
-			count++
 		}
 	}
 
 	sort.Sort(candidates)
 
-	return candidates, nil
+	return d.truncateCandidates(candidates), nil
+}
+
+// qualifiedLabelDetail prepends the namespace
+// to the detail of a label candidate
+func qualifiedLabelDetail(namespace, detail string) string {
+	if detail == "" {
+		return namespace
+	}
+	return fmt.Sprintf("%s, %s", namespace, detail)
+}
+
+// labelCandidatesFromReferenceTargets returns candidates for a label
+// based on addresses of known reference targets matching the given
+// reference scope and type
+func (d *PathDecoder) labelCandidatesFromReferenceTargets(ctx context.Context, ref schema.Reference, prefixRng, editRng hcl.Range) (lang.Candidates, error) {
+	candidates := lang.NewCandidates()
+
+	prefix, _ := d.bytesFromRange(prefixRng)
+	if d.isPrefixTooShort(ctx, string(prefix)) {
+		return candidates, nil
+	}
+
+	isQuoted := d.isQuotedLabelRange(editRng)
+
+	foundAddrs := make(map[string]bool, 0)
+	for _, target := range d.pathCtx.ReferenceTargets {
+		if len(target.Addr) == 0 || !targetMatchesLabelReference(target, ref) {
+			continue
+		}
+
+		addr := target.Addr.String()
+		if foundAddrs[addr] {
+			continue
+		}
+
+		penalty, ok := d.matchPrefix(addr, string(prefix))
+		if !ok {
+			continue
+		}
+		foundAddrs[addr] = true
+
+		newText := escapeQuotedLabel(addr)
+		if !isQuoted {
+			newText = `"` + newText + `"`
+		}
+
+		candidate := lang.Candidate{
+			Label:       addr,
+			Kind:        lang.LabelCandidateKind,
+			Detail:      target.FriendlyName(),
+			Description: target.Description,
+			TextEdit: lang.TextEdit{
+				NewText: newText,
+				Snippet: escapeSnippet(newText),
+				Range:   editRng,
+			},
+		}
+		candidates.List = append(candidates.List, d.withMatchSortText(candidate, penalty, string(prefix)))
+	}
+
+	sort.Sort(candidates)
+
+	return d.truncateCandidates(candidates), nil
 }
 
+func targetMatchesLabelReference(target reference.Target, ref schema.Reference) bool {
+	scopeIds := ref.ScopeIds()
+	if len(scopeIds) > 0 {
+		matchesScope := false
+		for _, scopeId := range scopeIds {
+			if target.MatchesScopeId(scopeId) {
+				matchesScope = true
+			}
+		}
+		if !matchesScope {
+			return false
+		}
+	}
+
+	if ref.OfType != cty.NilType {
+		return target.IsConvertibleToType(ref.OfType)
+	}
+
+	return true
+}
+
+// isQuotedLabelRange reports whether the given label range
+// is enclosed in quotes, as opposed to a bare identifier
+func (d *PathDecoder) isQuotedLabelRange(rng hcl.Range) bool {
+	src, err := d.bytesForFile(rng.Filename)
+	if err != nil || rng.Start.Byte < 1 || rng.Start.Byte > len(src) {
+		return true
+	}
+	return src[rng.Start.Byte-1] == '"'
+}
+
+// escapeQuotedLabel escapes the label value, such that it
+// can be inserted into a quoted label as a literal
+func escapeQuotedLabel(value string) string {
+	return quotedLabelEscaper.Replace(value)
+}
+
+var quotedLabelEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"${", "$${",
+	"%{", "%%{",
+)
+
+// escapeSnippet escapes characters with special meaning
+// in snippet syntax, such that the text is inserted as-is
+func escapeSnippet(text string) string {
+	return snippetEscaper.Replace(text)
+}
+
+var snippetEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`$`, `\$`,
+	`}`, `\}`,
+)
+
 // generateRequiredFieldsSnippet returns a properly formatted snippet of all required
 // fields (attributes, blocks, etc). It handles the main stanza declaration and calls
 // `requiredFieldsSnippet` to handle recursing through the body schema
@@ -5,10 +5,12 @@ package decoder
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -631,3 +633,438 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_qualifyLabelCandidates(t *testing.T) {
+	ctx := context.Background()
+	labelKey := func(value string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: value},
+			},
+		})
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:        "type",
+						IsDepKey:    true,
+						Completable: true,
+					},
+					{Name: "name"},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					labelKey("google_instance"): {
+						Namespace: "hashicorp/google",
+						Detail:    "Google Compute instance",
+					},
+					labelKey("aws_instance"): {
+						Namespace: "hashicorp/aws",
+					},
+					labelKey("local_thing"): {},
+				},
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte(`resource "" "foo" {
+}
+`), "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.decoderCtx.Completion.QualifyLabelCandidates = true
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{
+		Line:   1,
+		Column: 11,
+		Byte:   10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+		End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "aws_instance",
+			Detail: "hashicorp/aws",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "aws_instance",
+				Snippet: "aws_instance",
+			},
+			Kind:     lang.LabelCandidateKind,
+			SortText: "hashicorp/aws/aws_instance",
+		},
+		{
+			Label:  "google_instance",
+			Detail: "hashicorp/google, Google Compute instance",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "google_instance",
+				Snippet: "google_instance",
+			},
+			Kind:     lang.LabelCandidateKind,
+			SortText: "hashicorp/google/google_instance",
+		},
+		{
+			Label: "local_thing",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "local_thing",
+				Snippet: "local_thing",
+			},
+			Kind: lang.LabelCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestCompletionAtPos_escapedLabelCandidates(t *testing.T) {
+	ctx := context.Background()
+	labelKey := func(value string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: value},
+			},
+		})
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:        "type",
+						IsDepKey:    true,
+						Completable: true,
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					labelKey(`say "${hi}"`): {},
+					labelKey("foo.bar"):     {},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"quoted label",
+			`resource "" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "foo.bar",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+							End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+						},
+						NewText: "foo.bar",
+						Snippet: "foo.bar",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+				{
+					Label: `say "${hi}"`,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+							End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+						},
+						NewText: `say \"$${hi}\"`,
+						Snippet: `say \\"\$\${hi\}\\"`,
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"bare label",
+			`resource fo {
+}
+`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "foo.bar",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						},
+						NewText: `"foo.bar"`,
+						Snippet: `"foo.bar"`,
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_labelCandidatesFromReferenceTargets(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"moved": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name: "from",
+						CompletionTargets: &schema.Reference{
+							OfScopeId: lang.ScopeId("resource"),
+						},
+					},
+				},
+			},
+		},
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "foo"},
+			},
+			ScopeId: lang.ScopeId("resource"),
+			Name:    "resource",
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "bar"},
+			},
+			ScopeId: lang.ScopeId("resource"),
+			Name:    "resource",
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "foo"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"empty label",
+			`moved "" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_instance.bar",
+					Detail: "resource",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "aws_instance.bar",
+						Snippet: "aws_instance.bar",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+				{
+					Label:  "aws_instance.foo",
+					Detail: "resource",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "aws_instance.foo",
+						Snippet: "aws_instance.foo",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"label with prefix",
+			`moved "aws_instance.f" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 22, Byte: 21},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_instance.foo",
+					Detail: "resource",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
+						},
+						NewText: "aws_instance.foo",
+						Snippet: "aws_instance.foo",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_minPrefixForAutoComplete(t *testing.T) {
+	ctx := context.Background()
+	labelKey := func(value string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: value},
+			},
+		})
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:        "type",
+						IsDepKey:    true,
+						Completable: true,
+					},
+					{Name: "name"},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					labelKey("aws_instance"): {},
+					labelKey("aws_vpc"):      {},
+					labelKey("google_vpc"):   {},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName       string
+		cfg            string
+		pos            hcl.Pos
+		manual         bool
+		expectedLabels []string
+	}{
+		{
+			"auto below threshold",
+			`resource "a" "foo" {}`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			false,
+			[]string{},
+		},
+		{
+			"manual below threshold",
+			`resource "a" "foo" {}`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			true,
+			[]string{"aws_instance", "aws_vpc"},
+		},
+		{
+			"auto at threshold",
+			`resource "aws" "foo" {}`,
+			hcl.Pos{Line: 1, Column: 14, Byte: 13},
+			false,
+			[]string{"aws_instance", "aws_vpc"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.Completion.MinPrefixForAutoComplete = 3
+
+			reqCtx := ctx
+			if tc.manual {
+				reqCtx = WithManualCompletion(ctx)
+			}
+
+			candidates, err := d.CompletionAtPos(reqCtx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, candidate := range candidates.List {
+				labels = append(labels, candidate.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"net/url"
+	"sort"
 
 	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/lang"
@@ -37,6 +38,22 @@ func (d *PathDecoder) LinksInFile(filename string) ([]lang.Link, error) {
 func (d *PathDecoder) linksInBody(body *hclsyntax.Body, bodySchema *schema.BodySchema) ([]lang.Link, error) {
 	links := make([]lang.Link, 0)
 
+	for name, attr := range body.Attributes {
+		attrSchema, ok := bodySchema.Attributes[name]
+		if !ok || attrSchema.DocsLink == nil {
+			continue
+		}
+		u, err := d.docsURL(attrSchema.DocsLink.URL, "documentLink")
+		if err != nil {
+			continue
+		}
+		links = append(links, lang.Link{
+			URI:     u.String(),
+			Tooltip: attrSchema.DocsLink.Tooltip,
+			Range:   attr.NameRange,
+		})
+	}
+
 	for _, block := range body.Blocks {
 		blockSchema, ok := bodySchema.Blocks[block.Type]
 		if !ok {
@@ -44,15 +61,26 @@ func (d *PathDecoder) linksInBody(body *hclsyntax.Body, bodySchema *schema.BodyS
 			continue
 		}
 
-		// Currently only block bodies have links associated
-		if block.Body != nil {
-			depSchema, dk, result := schemahelper.NewBlockSchema(blockSchema).DependentBodySchema(block.AsHCLBlock())
-			if (result == schemahelper.LookupSuccessful || result == schemahelper.LookupPartiallySuccessful || result == schemahelper.NoDependentKeys) && depSchema.DocsLink != nil {
-				link := depSchema.DocsLink
-				u, err := d.docsURL(link.URL, "documentLink")
-				if err != nil {
-					continue
-				}
+		if blockSchema.DocsLink != nil {
+			u, err := d.docsURL(blockSchema.DocsLink.URL, "documentLink")
+			if err == nil {
+				links = append(links, lang.Link{
+					URI:     u.String(),
+					Tooltip: blockSchema.DocsLink.Tooltip,
+					Range:   block.TypeRange,
+				})
+			}
+		}
+
+		if block.Body == nil {
+			continue
+		}
+
+		depSchema, dk, result := schemahelper.NewBlockSchema(blockSchema).DependentBodySchema(block.AsHCLBlock())
+		if (result == schemahelper.LookupSuccessful || result == schemahelper.LookupPartiallySuccessful || result == schemahelper.NoDependentKeys) && depSchema != nil && depSchema.DocsLink != nil {
+			link := depSchema.DocsLink
+			u, err := d.docsURL(link.URL, "documentLink")
+			if err == nil {
 				for _, labelDep := range dk.Labels {
 					links = append(links, lang.Link{
 						URI:     u.String(),
@@ -70,8 +98,21 @@ func (d *PathDecoder) linksInBody(body *hclsyntax.Body, bodySchema *schema.BodyS
 			}
 		}
 
+		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+		if mergedSchema == nil {
+			continue
+		}
+		nestedLinks, err := d.linksInBody(block.Body, mergedSchema)
+		if err != nil {
+			return links, err
+		}
+		links = append(links, nestedLinks...)
 	}
 
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].Range.Start.Byte < links[j].Range.Start.Byte
+	})
+
 	return links, nil
 }
 
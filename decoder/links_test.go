@@ -189,6 +189,113 @@ func TestLinksInFileAttribute(t *testing.T) {
 	}
 }
 
+func TestLinksInFile_blockTypeAndAttribute(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				DocsLink: &schema.DocsLink{
+					URL:     "https://example.com/myblock",
+					Tooltip: "myblock docs",
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"str_attr": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							DocsLink: &schema.DocsLink{
+								URL: "https://example.com/myblock#str_attr",
+							},
+						},
+						"num_attr": {Constraint: schema.LiteralType{Type: cty.Number}},
+					},
+					Blocks: map[string]*schema.BlockSchema{
+						"nested": {
+							DocsLink: &schema.DocsLink{
+								URL: "https://example.com/myblock#nested",
+							},
+						},
+					},
+				},
+			},
+		},
+		Attributes: map[string]*schema.AttributeSchema{
+			"top_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				DocsLink: &schema.DocsLink{
+					URL: "https://example.com/top_attr",
+				},
+			},
+		},
+	}
+	testConfig := []byte(`top_attr = "foo"
+myblock "test" {
+  num_attr = 4
+  str_attr = "test"
+  nested {}
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testConfig, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	links, err := d.LinksInFile("test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLinks := []lang.Link{
+		{
+			URI: "https://example.com/top_attr",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			},
+		},
+		{
+			URI:     "https://example.com/myblock",
+			Tooltip: "myblock docs",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+				End:      hcl.Pos{Line: 2, Column: 8, Byte: 24},
+			},
+		},
+		{
+			URI: "https://example.com/myblock#str_attr",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 4, Column: 3, Byte: 51},
+				End:      hcl.Pos{Line: 4, Column: 11, Byte: 59},
+			},
+		},
+		{
+			URI: "https://example.com/myblock#nested",
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 3, Byte: 71},
+				End:      hcl.Pos{Line: 5, Column: 9, Byte: 77},
+			},
+		},
+	}
+
+	diff := cmp.Diff(expectedLinks, links)
+	if diff != "" {
+		t.Fatalf("unexpected links: %s", diff)
+	}
+}
+
 func TestLinksInFile_json(t *testing.T) {
 	f, pDiags := json.Parse([]byte(`{
 	"customblock": {
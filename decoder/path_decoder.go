@@ -17,6 +17,7 @@ type PathDecoder struct {
 	decoderCtx DecoderContext
 
 	// maxCandidates defines maximum number of completion candidates returned
+	// unless overridden via CompletionOptions.MaxCandidates
 	maxCandidates uint
 
 	// PrefillRequiredFields enriches label-based completion candidates
@@ -36,6 +37,14 @@ func (d *Decoder) Path(path lang.Path) (*PathDecoder, error) {
 	}, err
 }
 
+// candidateLimit returns the maximum number of completion candidates
+func (d *PathDecoder) candidateLimit() uint {
+	if d.decoderCtx.Completion.MaxCandidates != 0 {
+		return d.decoderCtx.Completion.MaxCandidates
+	}
+	return d.maxCandidates
+}
+
 func (d *PathDecoder) bytesForFile(file string) ([]byte, error) {
 	f, ok := d.pathCtx.Files[file]
 	if !ok {
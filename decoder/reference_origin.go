@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
 )
 
@@ -14,3 +15,18 @@ type ReferenceOrigin struct {
 }
 
 type ReferenceOrigins []ReferenceOrigin
+
+// ResolvedOrigin pairs a reference origin with the targets it resolves to
+type ResolvedOrigin struct {
+	Origin reference.Origin
+
+	// IsResolved indicates whether the origin matched any target
+	IsResolved bool
+
+	// Targets represents the addressable targets the origin resolves to.
+	// It may be empty even for a resolved origin, e.g. if none
+	// of the matching targets have a range.
+	Targets ReferenceTargets
+}
+
+type ResolvedOrigins []ResolvedOrigin
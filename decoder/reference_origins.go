@@ -61,7 +61,57 @@ func (d *Decoder) ReferenceOriginsTargetingPos(path lang.Path, file string, pos
 	return origins
 }
 
+// ReferenceOriginsInFile returns all reference origins in the given file
+// along with their resolution status, i.e. whether and which targets
+// each origin resolves to.
+func (d *Decoder) ReferenceOriginsInFile(path lang.Path, filename string) (ResolvedOrigins, error) {
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedOrigins := make(ResolvedOrigins, 0)
+	for _, origin := range pathCtx.ReferenceOrigins {
+		if origin.OriginRange().Filename != filename {
+			continue
+		}
+
+		targets, ok := d.referenceTargetsForOrigin(path, pathCtx, origin)
+		resolvedOrigins = append(resolvedOrigins, ResolvedOrigin{
+			Origin:     origin,
+			IsResolved: ok,
+			Targets:    targets,
+		})
+	}
+
+	sort.SliceStable(resolvedOrigins, func(i, j int) bool {
+		return resolvedOrigins[i].Origin.OriginRange().Start.Byte < resolvedOrigins[j].Origin.OriginRange().Start.Byte
+	})
+
+	return resolvedOrigins, nil
+}
+
+// CollectReferenceOrigins returns all reference origins found
+// within the files of the given path, including origins nested
+// in blocks, complex values and template interpolations.
+//
+// This is intended to (re)build the PathContext.ReferenceOrigins index.
+//
+// The context error is returned if ctx is cancelled during collection.
+func (d *Decoder) CollectReferenceOrigins(ctx context.Context, path lang.Path) (reference.Origins, error) {
+	pathDecoder, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pathDecoder.collectReferenceOrigins(ctx)
+}
+
 func (d *PathDecoder) CollectReferenceOrigins() (reference.Origins, error) {
+	return d.collectReferenceOrigins(context.Background())
+}
+
+func (d *PathDecoder) collectReferenceOrigins(ctx context.Context) (reference.Origins, error) {
 	refOrigins := make(reference.Origins, 0)
 	impliedOrigins := make([]schema.ImpliedOrigin, 0)
 
@@ -78,10 +128,14 @@ func (d *PathDecoder) CollectReferenceOrigins() (reference.Origins, error) {
 			continue
 		}
 
-		os, ios := d.referenceOriginsInBody(f.Body, d.pathCtx.Schema)
+		os, ios := d.referenceOriginsInBody(ctx, f.Body, d.pathCtx.Schema, nil)
 		refOrigins = append(refOrigins, os...)
 		impliedOrigins = append(impliedOrigins, ios...)
 	}
+	// origins may be incomplete if the request was cancelled in the meantime
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	for _, impliedOrigin := range impliedOrigins {
 		for _, origin := range refOrigins {
@@ -113,7 +167,13 @@ func (d *PathDecoder) CollectReferenceOrigins() (reference.Origins, error) {
 	return refOrigins, nil
 }
 
-func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.BodySchema) (reference.Origins, []schema.ImpliedOrigin) {
+// referenceOriginsInBody collects reference origins in the given body.
+//
+// selfAddr represents the address of the innermost enclosing block
+// which excludes self references (if any), see
+// BlockAddrSchema.ExcludeSelfReferences. Origins targeting that address
+// are left out unless self references are allowed in the body.
+func (d *PathDecoder) referenceOriginsInBody(ctx context.Context, body hcl.Body, bodySchema *schema.BodySchema, selfAddr lang.Address) (reference.Origins, []schema.ImpliedOrigin) {
 	origins := make(reference.Origins, 0)
 	impliedOrigins := make([]schema.ImpliedOrigin, 0)
 
@@ -121,17 +181,20 @@ func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.B
 		return origins, impliedOrigins
 	}
 
-	ctx := context.Background()
-
 	impliedOrigins = append(impliedOrigins, bodySchema.ImpliedOrigins...)
 	content := ast.DecodeBody(body, bodySchema)
 
 	for _, attr := range content.Attributes {
+		if ctx.Err() != nil {
+			return origins, impliedOrigins
+		}
 		var aSchema *schema.AttributeSchema
 		if bodySchema.Extensions != nil && bodySchema.Extensions.Count && attr.Name == "count" {
 			aSchema = schemahelper.CountAttributeSchema()
 		} else if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && attr.Name == "for_each" {
 			aSchema = schemahelper.ForEachAttributeSchema()
+		} else if bodySchema.Extensions != nil && bodySchema.Extensions.DependsOn && attr.Name == "depends_on" {
+			aSchema = schemahelper.DependsOnAttributeSchema(bodySchema.Extensions.DependsOnScopeIds)
 		} else {
 			var ok bool
 			aSchema, ok = bodySchema.Attributes[attr.Name]
@@ -169,16 +232,24 @@ func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.B
 			})
 		}
 
+		exprCtx := ctx
 		if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
-			ctx = schema.WithActiveSelfRefs(ctx)
+			exprCtx = schema.WithActiveSelfRefs(ctx)
 		}
 		expr := d.newExpression(attr.Expr, aSchema.Constraint)
 		if eType, ok := expr.(ReferenceOriginsExpression); ok {
-			origins = append(origins, eType.ReferenceOrigins(ctx)...)
+			exprOrigins := eType.ReferenceOrigins(exprCtx)
+			if !schema.ActiveSelfRefsFromContext(exprCtx) {
+				exprOrigins = withoutSelfReferences(exprOrigins, selfAddr)
+			}
+			origins = append(origins, exprOrigins...)
 		}
 	}
 
 	for _, block := range content.Blocks {
+		if ctx.Err() != nil {
+			return origins, impliedOrigins
+		}
 		if block.Body != nil {
 			bSchema, ok := bodySchema.Blocks[block.Type]
 			if !ok {
@@ -187,7 +258,14 @@ func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.B
 			}
 			mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.Block, bSchema)
 
-			os, ios := d.referenceOriginsInBody(block.Body, mergedSchema)
+			blockAddr := selfAddr
+			if bSchema.Address != nil && bSchema.Address.ExcludeSelfReferences {
+				if addr, ok := resolveBlockAddress(block.Block, bSchema); ok {
+					blockAddr = addr
+				}
+			}
+
+			os, ios := d.referenceOriginsInBody(ctx, block.Body, mergedSchema, blockAddr)
 			origins = append(origins, os...)
 			impliedOrigins = append(impliedOrigins, ios...)
 		}
@@ -195,3 +273,22 @@ func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.B
 
 	return origins, impliedOrigins
 }
+
+// withoutSelfReferences filters out local origins which target
+// the given (enclosing block) address or any of its attributes
+func withoutSelfReferences(origins reference.Origins, selfAddr lang.Address) reference.Origins {
+	if len(selfAddr) == 0 {
+		return origins
+	}
+
+	filtered := make(reference.Origins, 0, len(origins))
+	for _, origin := range origins {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if ok && len(localOrigin.Addr) >= len(selfAddr) &&
+			localOrigin.Addr.FirstSteps(uint(len(selfAddr))).Equals(selfAddr) {
+			continue
+		}
+		filtered = append(filtered, origin)
+	}
+	return filtered
+}
@@ -925,6 +925,241 @@ tup = [ var.three ]
 				},
 			},
 		},
+		{
+			"block referencing itself",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type"},
+							{Name: "name"},
+						},
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.LabelStep{Index: 0},
+								schema.LabelStep{Index: 1},
+							},
+							ExcludeSelfReferences: true,
+						},
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"attr":  {Constraint: schema.Reference{OfType: cty.String}},
+								"other": {Constraint: schema.Reference{OfType: cty.String}},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" "foo" {
+  attr = aws_instance.foo.id
+  other = aws_instance.bar.id
+}
+`,
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "bar"},
+						lang.AttrStep{Name: "id"},
+					},
+					Constraints: reference.OriginConstraints{
+						{OfType: cty.String},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   3,
+							Column: 11,
+							Byte:   71,
+						},
+						End: hcl.Pos{
+							Line:   3,
+							Column: 30,
+							Byte:   90,
+						},
+					},
+				},
+			},
+		},
+		{
+			"block referencing itself with self refs",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type"},
+							{Name: "name"},
+						},
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.LabelStep{Index: 0},
+								schema.LabelStep{Index: 1},
+							},
+							ExcludeSelfReferences: true,
+						},
+						Body: &schema.BodySchema{
+							Extensions: &schema.BodyExtensions{
+								SelfRefs: true,
+							},
+							Attributes: map[string]*schema.AttributeSchema{
+								"attr":  {Constraint: schema.Reference{OfType: cty.String}},
+								"other": {Constraint: schema.Reference{OfType: cty.String}},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" "foo" {
+  attr = aws_instance.foo.id
+  other = aws_instance.bar.id
+}
+`,
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "foo"},
+						lang.AttrStep{Name: "id"},
+					},
+					Constraints: reference.OriginConstraints{
+						{OfType: cty.String},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   2,
+							Column: 10,
+							Byte:   41,
+						},
+						End: hcl.Pos{
+							Line:   2,
+							Column: 29,
+							Byte:   60,
+						},
+					},
+				},
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "bar"},
+						lang.AttrStep{Name: "id"},
+					},
+					Constraints: reference.OriginConstraints{
+						{OfType: cty.String},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   3,
+							Column: 11,
+							Byte:   71,
+						},
+						End: hcl.Pos{
+							Line:   3,
+							Column: 30,
+							Byte:   90,
+						},
+					},
+				},
+			},
+		},
+		{
+			"nested block referencing enclosing block",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"variable": {
+						Labels: []*schema.LabelSchema{
+							{Name: "name"},
+						},
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.StaticStep{Name: "var"},
+								schema.LabelStep{Index: 0},
+							},
+						},
+						Body: &schema.BodySchema{
+							Blocks: map[string]*schema.BlockSchema{
+								"validation": {
+									Body: &schema.BodySchema{
+										Attributes: map[string]*schema.AttributeSchema{
+											"condition": {Constraint: schema.Reference{OfType: cty.Bool}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`variable "foo" {
+  validation {
+    condition = var.foo
+  }
+}
+`,
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Constraints: reference.OriginConstraints{
+						{OfType: cty.Bool},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   3,
+							Column: 17,
+							Byte:   48,
+						},
+						End: hcl.Pos{
+							Line:   3,
+							Column: 24,
+							Byte:   55,
+						},
+					},
+				},
+			},
+		},
+		{
+			"nested block referencing enclosing block excluding self references",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type"},
+							{Name: "name"},
+						},
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.LabelStep{Index: 0},
+								schema.LabelStep{Index: 1},
+							},
+							ExcludeSelfReferences: true,
+						},
+						Body: &schema.BodySchema{
+							Blocks: map[string]*schema.BlockSchema{
+								"lifecycle": {
+									Body: &schema.BodySchema{
+										Attributes: map[string]*schema.AttributeSchema{
+											"attr": {Constraint: schema.Reference{OfType: cty.String}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" "foo" {
+  lifecycle {
+    attr = aws_instance.foo.id
+  }
+}
+`,
+			reference.Origins{},
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%2d-%s", i, tc.name), func(t *testing.T) {
@@ -4,6 +4,8 @@
 package decoder
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -790,3 +792,266 @@ func TestCollectReferenceOrigins_nil_expr(t *testing.T) {
 		t.Fatalf("expected no targets, got %d", len(targets))
 	}
 }
+
+func TestReferenceOriginsInFile(t *testing.T) {
+	dirPath := t.TempDir()
+
+	resolvedOrigin := reference.LocalOrigin{
+		Addr: lang.Address{
+			lang.RootStep{Name: "var"},
+			lang.AttrStep{Name: "foo"},
+		},
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+		},
+	}
+	danglingOrigin := reference.LocalOrigin{
+		Addr: lang.Address{
+			lang.RootStep{Name: "var"},
+			lang.AttrStep{Name: "bar"},
+		},
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2, Column: 8, Byte: 22},
+			End:      hcl.Pos{Line: 2, Column: 15, Byte: 29},
+		},
+	}
+	otherFileOrigin := reference.LocalOrigin{
+		Addr: lang.Address{
+			lang.RootStep{Name: "var"},
+			lang.AttrStep{Name: "foo"},
+		},
+		Range: hcl.Range{
+			Filename: "other.tf",
+			Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+		},
+	}
+	targetRange := hcl.Range{
+		Filename: "variables.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 3, Column: 2, Byte: 22},
+	}
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				ReferenceOrigins: reference.Origins{
+					danglingOrigin,
+					otherFileOrigin,
+					resolvedOrigin,
+				},
+				ReferenceTargets: reference.Targets{
+					{
+						Addr: lang.Address{
+							lang.RootStep{Name: "var"},
+							lang.AttrStep{Name: "foo"},
+						},
+						Type:     cty.String,
+						RangePtr: targetRange.Ptr(),
+					},
+				},
+			},
+		},
+	})
+
+	origins, err := d.ReferenceOriginsInFile(lang.Path{Path: dirPath}, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOrigins := ResolvedOrigins{
+		{
+			Origin:     resolvedOrigin,
+			IsResolved: true,
+			Targets: ReferenceTargets{
+				{
+					OriginRange: resolvedOrigin.Range,
+					Path:        lang.Path{Path: dirPath},
+					Range:       targetRange,
+				},
+			},
+		},
+		{
+			Origin:     danglingOrigin,
+			IsResolved: false,
+			Targets:    ReferenceTargets{},
+		},
+	}
+
+	if diff := cmp.Diff(expectedOrigins, origins, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("mismatch of resolved origins: %s", diff)
+	}
+}
+
+func TestDecoder_CollectReferenceOrigins(t *testing.T) {
+	dirPath := t.TempDir()
+
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"}, {Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"tags": {
+							IsOptional: true,
+							Constraint: schema.Object{
+								Attributes: schema.ObjectAttributes{
+									"name": {
+										Constraint: schema.Reference{OfType: cty.String},
+									},
+								},
+							},
+						},
+						"ids": {
+							IsOptional: true,
+							Constraint: schema.List{
+								Elem: schema.Reference{OfType: cty.String},
+							},
+						},
+					},
+					Blocks: map[string]*schema.BlockSchema{
+						"nested": {
+							Body: &schema.BodySchema{
+								Attributes: map[string]*schema.AttributeSchema{
+									"greeting": {
+										IsOptional: true,
+										Constraint: schema.AnyExpression{OfType: cty.String},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := `resource "foo" "bar" {
+  tags = {
+    name = var.name
+  }
+  ids = [ var.first, var.second ]
+  nested {
+    greeting = "hello ${var.who}"
+  }
+}
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	})
+
+	origins, err := d.CollectReferenceOrigins(context.Background(), lang.Path{Path: dirPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOrigins := reference.Origins{
+		reference.LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "name"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 12, Byte: 45},
+				End:      hcl.Pos{Line: 3, Column: 20, Byte: 53},
+			},
+			Constraints: reference.OriginConstraints{
+				{OfType: cty.String},
+			},
+		},
+		reference.LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "first"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 11, Byte: 68},
+				End:      hcl.Pos{Line: 5, Column: 20, Byte: 77},
+			},
+			Constraints: reference.OriginConstraints{
+				{OfType: cty.String},
+			},
+		},
+		reference.LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "second"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 22, Byte: 79},
+				End:      hcl.Pos{Line: 5, Column: 32, Byte: 89},
+			},
+			Constraints: reference.OriginConstraints{
+				{OfType: cty.String},
+			},
+		},
+		reference.LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "who"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 25, Byte: 127},
+				End:      hcl.Pos{Line: 7, Column: 32, Byte: 134},
+			},
+			Constraints: reference.OriginConstraints{
+				{OfType: cty.String},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedOrigins, origins, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected origins: %s", diff)
+	}
+}
+
+func TestDecoder_CollectReferenceOrigins_cancelledContext(t *testing.T) {
+	dirPath := t.TempDir()
+
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				IsOptional: true,
+				Constraint: schema.Reference{OfType: cty.String},
+			},
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte(`attr = var.name
+`), "test.tf", hcl.InitialPos)
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := d.CollectReferenceOrigins(ctx, lang.Path{Path: dirPath})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, given: %#v", err)
+	}
+}
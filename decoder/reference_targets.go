@@ -34,54 +34,150 @@ func (d *Decoder) ReferenceTargetsForOriginAtPos(path lang.Path, file string, po
 	}
 
 	for _, origin := range origins {
-		targetCtx := pathCtx
-		targetPath := path
-
-		if directOrigin, ok := origin.(reference.DirectOrigin); ok {
-			matchingTargets = append(matchingTargets, &ReferenceTarget{
-				OriginRange: origin.OriginRange(),
-				Path:        directOrigin.TargetPath,
-				Range:       directOrigin.TargetRange,
-				DefRangePtr: nil,
-			})
-			continue
-		}
-		if pathOrigin, ok := origin.(reference.PathOrigin); ok {
-			ctx, err := d.pathReader.PathContext(pathOrigin.TargetPath)
-			if err != nil {
-				continue
-			}
-			targetCtx = ctx
-			targetPath = pathOrigin.TargetPath
-		}
+		targets, _ := d.referenceTargetsForOrigin(path, pathCtx, origin)
+		matchingTargets = append(matchingTargets, targets...)
+	}
 
-		matchableOrigin, ok := origin.(reference.MatchableOrigin)
-		if !ok {
-			continue
-		}
-		targets, ok := targetCtx.ReferenceTargets.Match(matchableOrigin)
-		if !ok {
-			// target not found
-			continue
-		}
+	return matchingTargets, nil
+}
+
+// ReferenceTargetForPos returns the single most specific target,
+// i.e. the one with the deepest address, of the reference origin
+// at the given position, such as for "Go to Definition".
+//
+// nil is returned if there is no origin at the position or if
+// the origin doesn't resolve to any addressable target.
+func (d *Decoder) ReferenceTargetForPos(path lang.Path, file string, pos hcl.Pos) (*reference.Target, error) {
+	target, _, err := d.referenceTargetForOriginAtPos(path, file, pos)
+	return target, err
+}
+
+// referenceTargetForOriginAtPos returns the most specific addressable
+// target of the origin at the given position along with the path
+// in which the target was found.
+func (d *Decoder) referenceTargetForOriginAtPos(path lang.Path, file string, pos hcl.Pos) (*reference.Target, lang.Path, error) {
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return nil, path, err
+	}
+
+	origins, ok := pathCtx.ReferenceOrigins.AtPos(file, pos)
+	if !ok {
+		return nil, path, nil
+	}
+
+	var bestTarget *reference.Target
+	bestPath := path
+	for _, origin := range origins {
+		targets, targetPath, _ := d.targetsForOrigin(path, pathCtx, origin)
 		for _, target := range targets {
 			if target.RangePtr == nil {
 				// target is not addressable
 				continue
 			}
-			matchingTargets = append(matchingTargets, &ReferenceTarget{
-				OriginRange: origin.OriginRange(),
-				Path:        targetPath,
-				Range:       *target.RangePtr,
-				DefRangePtr: target.DefRangePtr,
-			})
+			if bestTarget == nil || targetAddressDepth(target) > targetAddressDepth(*bestTarget) {
+				target := target
+				bestTarget = &target
+				bestPath = targetPath
+			}
 		}
 	}
 
-	return matchingTargets, nil
+	return bestTarget, bestPath, nil
+}
+
+func targetAddressDepth(target reference.Target) int {
+	if len(target.Addr) > 0 {
+		return len(target.Addr)
+	}
+	return len(target.LocalAddr)
+}
+
+// referenceTargetsForOrigin returns addressable targets matching the given
+// origin and whether any target (addressable or not) was matched at all.
+func (d *Decoder) referenceTargetsForOrigin(path lang.Path, pathCtx *PathContext, origin reference.Origin) (ReferenceTargets, bool) {
+	targets, targetPath, ok := d.targetsForOrigin(path, pathCtx, origin)
+	if !ok {
+		return ReferenceTargets{}, false
+	}
+
+	matchingTargets := make(ReferenceTargets, 0)
+	for _, target := range targets {
+		if target.RangePtr == nil {
+			// target is not addressable
+			continue
+		}
+		matchingTargets = append(matchingTargets, &ReferenceTarget{
+			OriginRange: origin.OriginRange(),
+			Path:        targetPath,
+			Range:       *target.RangePtr,
+			DefRangePtr: target.DefRangePtr,
+		})
+	}
+
+	return matchingTargets, true
+}
+
+// targetsForOrigin resolves the given origin to the matching targets,
+// along with the path in which they were found, and reports whether
+// any target was matched at all.
+//
+// Direct origins resolve to a target with the range they point to.
+func (d *Decoder) targetsForOrigin(path lang.Path, pathCtx *PathContext, origin reference.Origin) (reference.Targets, lang.Path, bool) {
+	if directOrigin, ok := origin.(reference.DirectOrigin); ok {
+		targetRange := directOrigin.TargetRange
+		return reference.Targets{
+			{
+				RangePtr: &targetRange,
+			},
+		}, directOrigin.TargetPath, true
+	}
+
+	targetCtx := pathCtx
+	targetPath := path
+	if pathOrigin, ok := origin.(reference.PathOrigin); ok {
+		ctx, err := d.pathReader.PathContext(pathOrigin.TargetPath)
+		if err != nil {
+			return reference.Targets{}, path, false
+		}
+		targetCtx = ctx
+		targetPath = pathOrigin.TargetPath
+	}
+
+	matchableOrigin, ok := origin.(reference.MatchableOrigin)
+	if !ok {
+		return reference.Targets{}, path, false
+	}
+	targets, ok := targetCtx.ReferenceTargets.Match(matchableOrigin)
+	if !ok {
+		// target not found
+		return reference.Targets{}, path, false
+	}
+
+	return targets, targetPath, true
+}
+
+// CollectReferenceTargets returns all addressable targets declared
+// within the files of the given path, as derived from the schema.
+//
+// This is intended to (re)build the PathContext.ReferenceTargets index,
+// rather than to resolve any particular origin.
+//
+// The context error is returned if ctx is cancelled during collection.
+func (d *Decoder) CollectReferenceTargets(ctx context.Context, path lang.Path) (reference.Targets, error) {
+	pathDecoder, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pathDecoder.collectReferenceTargets(ctx)
 }
 
 func (d *PathDecoder) CollectReferenceTargets() (reference.Targets, error) {
+	return d.collectReferenceTargets(context.Background())
+}
+
+func (d *PathDecoder) collectReferenceTargets(ctx context.Context) (reference.Targets, error) {
 	if d.pathCtx.Schema == nil {
 		// unable to collect reference targets without schema
 		return nil, &NoSchemaError{}
@@ -95,13 +191,17 @@ func (d *PathDecoder) CollectReferenceTargets() (reference.Targets, error) {
 			// skip unparseable file
 			continue
 		}
-		refs = append(refs, d.decodeReferenceTargetsForBody(f.Body, nil, d.pathCtx.Schema)...)
+		refs = append(refs, d.decodeReferenceTargetsForBody(ctx, f.Body, nil, d.pathCtx.Schema)...)
+	}
+	// targets may be incomplete if the request was cancelled in the meantime
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
 	return refs, nil
 }
 
-func (d *PathDecoder) decodeReferenceTargetsForBody(body hcl.Body, parentBlock *ast.BlockContent, bodySchema *schema.BodySchema) reference.Targets {
+func (d *PathDecoder) decodeReferenceTargetsForBody(ctx context.Context, body hcl.Body, parentBlock *ast.BlockContent, bodySchema *schema.BodySchema) reference.Targets {
 	refs := make(reference.Targets, 0)
 
 	if bodySchema == nil {
@@ -110,7 +210,18 @@ func (d *PathDecoder) decodeReferenceTargetsForBody(body hcl.Body, parentBlock *
 
 	content := ast.DecodeBody(body, bodySchema)
 
-	for _, attr := range content.Attributes {
+	// iterate attributes in a stable order to keep targets deterministic
+	attrNames := make([]string, 0, len(content.Attributes))
+	for name := range content.Attributes {
+		attrNames = append(attrNames, name)
+	}
+	sort.Strings(attrNames)
+
+	for _, name := range attrNames {
+		if ctx.Err() != nil {
+			return refs
+		}
+		attr := content.Attributes[name]
 		if bodySchema.Extensions != nil {
 			if bodySchema.Extensions.Count && attr.Name == "count" && content.RangePtr != nil {
 				refs = append(refs, countIndexReferenceTarget(attr, *content.RangePtr))
@@ -134,15 +245,22 @@ func (d *PathDecoder) decodeReferenceTargetsForBody(body hcl.Body, parentBlock *
 	}
 
 	for _, blk := range content.Blocks {
+		if ctx.Err() != nil {
+			return refs
+		}
 		bSchema, ok := bodySchema.Blocks[blk.Type]
 		if !ok {
 			// unknown block (no schema)
 			continue
 		}
 
+		if blk.Type == "dynamic" && bodySchema.Extensions != nil && bodySchema.Extensions.DynamicBlocks {
+			refs = append(refs, dynamicIteratorReferenceTargets(blk.Block)...)
+		}
+
 		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(blk.Block, bSchema)
 
-		iRefs := d.decodeReferenceTargetsForBody(blk.Body, blk, mergedSchema)
+		iRefs := d.decodeReferenceTargetsForBody(ctx, blk.Body, blk, mergedSchema)
 		refs = append(refs, iRefs...)
 
 		addr, ok := resolveBlockAddress(blk.Block, bSchema)
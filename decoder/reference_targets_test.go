@@ -4,6 +4,7 @@
 package decoder
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -355,6 +356,143 @@ func TestReferenceTargetForOriginAtPos(t *testing.T) {
 	}
 }
 
+func TestReferenceTargetForPos(t *testing.T) {
+	dirPath := t.TempDir()
+
+	originRng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+	}
+	origin := reference.LocalOrigin{
+		Addr: lang.Address{
+			lang.RootStep{Name: "var"},
+			lang.AttrStep{Name: "foo"},
+			lang.AttrStep{Name: "bar"},
+		},
+		Constraints: reference.OriginConstraints{
+			{OfType: cty.String},
+		},
+		Range: originRng,
+	}
+	nestedTarget := reference.Target{
+		Addr: lang.Address{
+			lang.RootStep{Name: "var"},
+			lang.AttrStep{Name: "foo"},
+			lang.AttrStep{Name: "bar"},
+		},
+		Type: cty.String,
+		RangePtr: &hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 3, Column: 3, Byte: 30},
+			End:      hcl.Pos{Line: 3, Column: 12, Byte: 39},
+		},
+	}
+	parentTarget := reference.Target{
+		Addr: lang.Address{
+			lang.RootStep{Name: "var"},
+			lang.AttrStep{Name: "foo"},
+		},
+		Type: cty.DynamicPseudoType,
+		RangePtr: &hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 2, Column: 1, Byte: 12},
+			End:      hcl.Pos{Line: 4, Column: 2, Byte: 42},
+		},
+		NestedTargets: reference.Targets{nestedTarget},
+	}
+
+	testCases := []struct {
+		name           string
+		pathCtx        *PathContext
+		pos            hcl.Pos
+		expectedTarget *reference.Target
+	}{
+		{
+			"no origin at position",
+			&PathContext{
+				ReferenceOrigins: reference.Origins{origin},
+				ReferenceTargets: reference.Targets{parentTarget},
+			},
+			hcl.Pos{Line: 2, Column: 1, Byte: 12},
+			nil,
+		},
+		{
+			"origin without target",
+			&PathContext{
+				ReferenceOrigins: reference.Origins{origin},
+				ReferenceTargets: reference.Targets{},
+			},
+			hcl.InitialPos,
+			nil,
+		},
+		{
+			"most specific of overlapping targets",
+			&PathContext{
+				ReferenceOrigins: reference.Origins{origin},
+				ReferenceTargets: reference.Targets{parentTarget},
+			},
+			hcl.InitialPos,
+			&nestedTarget,
+		},
+		{
+			"partial match",
+			&PathContext{
+				ReferenceOrigins: reference.Origins{origin},
+				ReferenceTargets: reference.Targets{
+					{
+						Addr:     parentTarget.Addr,
+						Type:     cty.DynamicPseudoType,
+						RangePtr: parentTarget.RangePtr,
+					},
+				},
+			},
+			hcl.InitialPos,
+			&reference.Target{
+				Addr:     parentTarget.Addr,
+				Type:     cty.DynamicPseudoType,
+				RangePtr: parentTarget.RangePtr,
+			},
+		},
+		{
+			"direct origin",
+			&PathContext{
+				ReferenceOrigins: reference.Origins{
+					reference.DirectOrigin{
+						Range:       originRng,
+						TargetPath:  lang.Path{Path: dirPath},
+						TargetRange: *nestedTarget.RangePtr,
+					},
+				},
+				ReferenceTargets: reference.Targets{},
+			},
+			hcl.InitialPos,
+			&reference.Target{
+				RangePtr: nestedTarget.RangePtr,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: tc.pathCtx,
+				},
+			})
+
+			target, err := d.ReferenceTargetForPos(lang.Path{Path: dirPath}, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedTarget, target, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("mismatch of reference target: %s", diff)
+			}
+		})
+	}
+}
+
 func TestCollectReferenceTargets_nil_expr(t *testing.T) {
 	// provider:: is not a traversal expression, so hcl will return a ExprSyntaxError which needs to be handled
 	f, _ := hclsyntax.ParseConfig([]byte(`attr = provider::`), "test.tf", hcl.InitialPos)
@@ -378,3 +516,158 @@ func TestCollectReferenceTargets_nil_expr(t *testing.T) {
 		t.Fatalf("expected no targets, got %d", len(targets))
 	}
 }
+
+func TestDecoder_CollectReferenceTargets(t *testing.T) {
+	dirPath := t.TempDir()
+
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"}, {Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					AsReference: true,
+				},
+				Body: &schema.BodySchema{
+					Blocks: map[string]*schema.BlockSchema{
+						"listener": {
+							Labels: []*schema.LabelSchema{
+								{Name: "name"},
+							},
+							Address: &schema.BlockAddrSchema{
+								Steps: []schema.AddrStep{
+									schema.StaticStep{Name: "listener"},
+									schema.LabelStep{Index: 0},
+								},
+								AsReference: true,
+							},
+							Body: &schema.BodySchema{
+								Attributes: map[string]*schema.AttributeSchema{
+									"port": {
+										IsOptional: true,
+										Constraint: schema.LiteralType{Type: cty.Number},
+										Address: &schema.AttributeAddrSchema{
+											Steps: []schema.AddrStep{
+												schema.StaticStep{Name: "port"},
+												schema.AttrNameStep{},
+											},
+											AsReference: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	b := `resource "aws_lb" "web" {
+  listener "http" {
+    port = 80
+  }
+}
+`
+	a := `resource "aws_instance" "app" {
+  listener "https" {
+    port = 443
+  }
+}
+`
+	fb, _ := hclsyntax.ParseConfig([]byte(b), "b.tf", hcl.InitialPos)
+	fa, _ := hclsyntax.ParseConfig([]byte(a), "a.tf", hcl.InitialPos)
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"b.tf": fb,
+					"a.tf": fa,
+				},
+			},
+		},
+	})
+
+	ctx := context.Background()
+	targets, err := d.CollectReferenceTargets(ctx, lang.Path{Path: dirPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addrs := make([]string, 0)
+	for _, target := range targets {
+		addrs = append(addrs, target.Addr.String())
+	}
+	expectedAddrs := []string{
+		"aws_instance.app",
+		"listener.https",
+		"port.port",
+		"aws_lb.web",
+		"listener.http",
+		"port.port",
+	}
+	if diff := cmp.Diff(expectedAddrs, addrs); diff != "" {
+		t.Fatalf("unexpected target addresses: %s", diff)
+	}
+
+	for i := 0; i < 10; i++ {
+		again, err := d.CollectReferenceTargets(ctx, lang.Path{Path: dirPath})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(targets, again, ctydebug.CmpOptions); diff != "" {
+			t.Fatalf("non-deterministic targets: %s", diff)
+		}
+	}
+}
+
+func TestDecoder_CollectReferenceTargets_cancelledContext(t *testing.T) {
+	dirPath := t.TempDir()
+
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"}, {Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					AsReference: true,
+				},
+				Body: &schema.BodySchema{},
+			},
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte(`resource "aws_instance" "app" {
+}
+`), "test.tf", hcl.InitialPos)
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := d.CollectReferenceTargets(ctx, lang.Path{Path: dirPath})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, given: %#v", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ReferencesToRename returns all ranges which need to change
+// in order to consistently rename the addressable block or attribute
+// at the given position, or the one targeted by a reference there.
+//
+// The ranges include the defining label (or attribute name) and
+// the relevant step of every traversal referring to it, across
+// all known paths. Origins in which the step cannot be located
+// are left out, rather than renaming the whole traversal.
+func (d *Decoder) ReferencesToRename(ctx context.Context, path lang.Path, file string, pos hcl.Pos) ([]hcl.Range, error) {
+	target, targetPath, err := d.referenceTargetForOriginAtPos(path, file, pos)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		target, err = d.definedTargetAtPos(path, file, pos)
+		if err != nil {
+			return nil, err
+		}
+		targetPath = path
+	}
+	if target == nil || target.DefRangePtr == nil || len(target.Addr) == 0 {
+		return nil, &PositionalError{
+			Filename: file,
+			Pos:      pos,
+			Msg:      "no addressable symbol found",
+		}
+	}
+
+	name, ok := addressStepName(target.Addr[len(target.Addr)-1])
+	if !ok {
+		return nil, &PositionalError{
+			Filename: file,
+			Pos:      pos,
+			Msg:      "symbol cannot be renamed",
+		}
+	}
+
+	targetCtx, err := d.pathReader.PathContext(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	defRng, ok := definitionNameRange(targetCtx, *target.DefRangePtr, name)
+	if !ok {
+		return nil, &PositionalError{
+			Filename: file,
+			Pos:      pos,
+			Msg:      "symbol cannot be renamed",
+		}
+	}
+
+	ranges := []hcl.Range{defRng}
+	seen := map[hcl.Range]bool{
+		defRng: true,
+	}
+
+	stepIdx := len(target.Addr) - 1
+	for _, p := range d.pathReader.Paths(ctx) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pathCtx, err := d.pathReader.PathContext(p)
+		if err != nil {
+			continue
+		}
+
+		for _, origin := range pathCtx.ReferenceOrigins.Match(p, *target, targetPath) {
+			rng, ok := originStepRange(pathCtx, origin.OriginRange(), stepIdx, name)
+			if !ok || seen[rng] {
+				continue
+			}
+			seen[rng] = true
+			ranges = append(ranges, rng)
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].Filename != ranges[j].Filename {
+			return ranges[i].Filename < ranges[j].Filename
+		}
+		return ranges[i].Start.Byte < ranges[j].Start.Byte
+	})
+
+	return ranges, nil
+}
+
+// definedTargetAtPos returns the most specific target
+// whose definition (block header or attribute name)
+// contains the given position
+func (d *Decoder) definedTargetAtPos(path lang.Path, file string, pos hcl.Pos) (*reference.Target, error) {
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, ok := pathCtx.ReferenceTargets.InnermostAtPos(file, pos)
+	if !ok {
+		return nil, nil
+	}
+
+	var bestTarget *reference.Target
+	for _, target := range targets {
+		if target.DefRangePtr == nil || !target.DefRangePtr.ContainsPos(pos) {
+			continue
+		}
+		if bestTarget == nil || targetAddressDepth(target) > targetAddressDepth(*bestTarget) {
+			target := target
+			bestTarget = &target
+		}
+	}
+
+	return bestTarget, nil
+}
+
+func addressStepName(step lang.AddressStep) (string, bool) {
+	switch s := step.(type) {
+	case lang.RootStep:
+		return s.Name, true
+	case lang.AttrStep:
+		return s.Name, true
+	}
+	return "", false
+}
+
+// definitionNameRange finds the range of the label or attribute name
+// carrying the given name within the block or attribute defined
+// at defRng
+func definitionNameRange(pathCtx *PathContext, defRng hcl.Range, name string) (hcl.Range, bool) {
+	f, ok := pathCtx.Files[defRng.Filename]
+	if !ok {
+		return hcl.Range{}, false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return hcl.Range{}, false
+	}
+
+	return definitionNameRangeInBody(body, defRng, name)
+}
+
+func definitionNameRangeInBody(body *hclsyntax.Body, defRng hcl.Range, name string) (hcl.Range, bool) {
+	for _, attr := range body.Attributes {
+		if attr.NameRange == defRng && attr.Name == name {
+			return attr.NameRange, true
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if block.DefRange() == defRng {
+			// prefer the last label as the most specific one
+			for i := len(block.Labels) - 1; i >= 0; i-- {
+				if block.Labels[i] == name {
+					return labelContentRange(block.LabelRanges[i], name), true
+				}
+			}
+			return hcl.Range{}, false
+		}
+		if block.Range().Overlaps(defRng) {
+			return definitionNameRangeInBody(block.Body, defRng, name)
+		}
+	}
+
+	return hcl.Range{}, false
+}
+
+// originStepRange returns the range of the traversal step at the given
+// index within the origin, if the step can be determined
+// and carries the given name.
+func originStepRange(pathCtx *PathContext, originRng hcl.Range, stepIdx int, name string) (hcl.Range, bool) {
+	f, ok := pathCtx.Files[originRng.Filename]
+	if !ok {
+		return hcl.Range{}, false
+	}
+	if originRng.Start.Byte < 0 || originRng.End.Byte > len(f.Bytes) {
+		return hcl.Range{}, false
+	}
+
+	traversal, diags := hclsyntax.ParseTraversalAbs(originRng.SliceBytes(f.Bytes), originRng.Filename, originRng.Start)
+	if diags.HasErrors() || stepIdx >= len(traversal) {
+		return hcl.Range{}, false
+	}
+
+	rng := traversal[stepIdx].SourceRange()
+	switch step := traversal[stepIdx].(type) {
+	case hcl.TraverseRoot:
+		if step.Name != name {
+			return hcl.Range{}, false
+		}
+	case hcl.TraverseAttr:
+		if step.Name != name {
+			return hcl.Range{}, false
+		}
+		// exclude the leading dot
+		rng.Start.Byte++
+		rng.Start.Column++
+	default:
+		return hcl.Range{}, false
+	}
+	return rng, true
+}
+
+// labelContentRange returns the range of the label name
+// without any surrounding quotes
+func labelContentRange(rng hcl.Range, name string) hcl.Range {
+	if rng.End.Byte-rng.Start.Byte != len(name)+2 {
+		return rng
+	}
+	rng.Start.Byte++
+	rng.Start.Column++
+	rng.End.Byte--
+	rng.End.Column--
+	return rng
+}
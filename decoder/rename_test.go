@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestReferencesToRename(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					FriendlyName: "resource",
+					ScopeId:      lang.ScopeId("resource"),
+					AsReference:  true,
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"attr": {
+							Constraint: schema.Reference{OfScopeId: lang.ScopeId("resource")},
+							IsOptional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	mainCfg := `resource "aws_instance" "foo" {
+}
+resource "aws_instance" "bar" {
+  attr = aws_instance.foo
+}
+`
+	otherCfg := `resource "aws_instance" "baz" {
+  attr = aws_instance.foo
+}
+`
+
+	fooRanges := []hcl.Range{
+		{
+			Filename: "main.tf",
+			Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+			End:      hcl.Pos{Line: 1, Column: 29, Byte: 28},
+		},
+		{
+			Filename: "main.tf",
+			Start:    hcl.Pos{Line: 4, Column: 23, Byte: 88},
+			End:      hcl.Pos{Line: 4, Column: 26, Byte: 91},
+		},
+		{
+			Filename: "other.tf",
+			Start:    hcl.Pos{Line: 2, Column: 23, Byte: 54},
+			End:      hcl.Pos{Line: 2, Column: 26, Byte: 57},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		file           string
+		pos            hcl.Pos
+		expectedRanges []hcl.Range
+		expectedErr    bool
+	}{
+		{
+			"definition label",
+			"main.tf",
+			hcl.Pos{Line: 1, Column: 27, Byte: 26},
+			fooRanges,
+			false,
+		},
+		{
+			"reference",
+			"other.tf",
+			hcl.Pos{Line: 2, Column: 12, Byte: 43},
+			fooRanges,
+			false,
+		},
+		{
+			"unreferenced definition",
+			"main.tf",
+			hcl.Pos{Line: 3, Column: 2, Byte: 35},
+			[]hcl.Range{
+				{
+					Filename: "main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 26, Byte: 59},
+					End:      hcl.Pos{Line: 3, Column: 29, Byte: 62},
+				},
+			},
+			false,
+		},
+		{
+			"not addressable",
+			"main.tf",
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			nil,
+			true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			mainFile, _ := hclsyntax.ParseConfig([]byte(mainCfg), "main.tf", hcl.InitialPos)
+			otherFile, _ := hclsyntax.ParseConfig([]byte(otherCfg), "other.tf", hcl.InitialPos)
+			dirPath := t.TempDir()
+			pathCtx := &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"main.tf":  mainFile,
+					"other.tf": otherFile,
+				},
+			}
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: pathCtx,
+				},
+			})
+
+			path := lang.Path{Path: dirPath}
+			pd, err := d.Path(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceTargets, err = pd.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceOrigins, err = pd.CollectReferenceOrigins()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ranges, err := d.ReferencesToRename(context.Background(), path, tc.file, tc.pos)
+			if tc.expectedErr {
+				posErr := &PositionalError{}
+				if !errors.As(err, &posErr) {
+					t.Fatalf("expected PositionalError, given: %#v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedRanges, ranges); diff != "" {
+				t.Fatalf("unexpected ranges: %s", diff)
+			}
+
+			for _, rng := range ranges {
+				name := string(rng.SliceBytes(pathCtx.Files[rng.Filename].Bytes))
+				if name != "foo" && name != "bar" {
+					t.Fatalf("expected range %s to cover only the name, given: %q", rng, name)
+				}
+			}
+		})
+	}
+}
+
+func TestOriginStepRange_unresolvable(t *testing.T) {
+	cfg := `attr = aws_instance.foo
+other = "aws_instance.foo"
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	pathCtx := &PathContext{
+		Files: map[string]*hcl.File{
+			"main.tf": f,
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		originRng hcl.Range
+		stepIdx   int
+		stepName  string
+	}{
+		{
+			"step out of range",
+			hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+			},
+			2,
+			"foo",
+		},
+		{
+			"mismatching step name",
+			hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+			},
+			1,
+			"bar",
+		},
+		{
+			"unparseable traversal",
+			hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 9, Byte: 32},
+				End:      hcl.Pos{Line: 2, Column: 27, Byte: 50},
+			},
+			1,
+			"foo",
+		},
+		{
+			"unknown file",
+			hcl.Range{
+				Filename: "other.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+			},
+			1,
+			"foo",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			rng, ok := originStepRange(pathCtx, tc.originRng, tc.stepIdx, tc.stepName)
+			if ok {
+				t.Fatalf("expected no range, given: %#v", rng)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// MissingRequiredAttributes returns sorted names of required attributes
+// which are not yet set in the innermost body containing the given position.
+//
+// Block bodies are resolved through any dependent body schemas, i.e.
+// attributes required only for a particular label value are included.
+// An empty slice is returned if all required attributes are set
+// or if no schema is known for the body.
+func (d *PathDecoder) MissingRequiredAttributes(filename string, pos hcl.Pos) ([]string, error) {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	rootBody, err := d.bodyForFileAndPos(filename, f, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	body, bodySchema := d.innermostBodyAtPos(rootBody, d.pathCtx.Schema, pos)
+
+	return missingRequiredAttributes(body, bodySchema), nil
+}
+
+func (d *PathDecoder) innermostBodyAtPos(body *hclsyntax.Body, bodySchema *schema.BodySchema, pos hcl.Pos) (*hclsyntax.Body, *schema.BodySchema) {
+	if bodySchema == nil {
+		return body, nil
+	}
+
+	for _, block := range body.Blocks {
+		if block.Body == nil || !block.Body.Range().ContainsPos(pos) {
+			continue
+		}
+
+		blockSchema, ok := bodySchema.Blocks[block.Type]
+		if !ok {
+			return block.Body, nil
+		}
+
+		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+		return d.innermostBodyAtPos(block.Body, mergedSchema, pos)
+	}
+
+	return body, bodySchema
+}
+
+func missingRequiredAttributes(body *hclsyntax.Body, bodySchema *schema.BodySchema) []string {
+	names := make([]string, 0)
+	if bodySchema == nil {
+		return names
+	}
+
+	for name, attr := range bodySchema.Attributes {
+		if !attr.IsRequired {
+			continue
+		}
+		if _, ok := body.Attributes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDecoder_MissingRequiredAttributes(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"top_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type", IsDepKey: true},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+							IsOptional: true,
+						},
+						"name": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsRequired: true,
+						},
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "aws_instance"},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"instance_type": {
+								Constraint: schema.LiteralType{Type: cty.String},
+								IsRequired: true,
+							},
+							"ami": {
+								Constraint: schema.LiteralType{Type: cty.String},
+								IsRequired: true,
+							},
+							"tags": {
+								Constraint: schema.LiteralType{Type: cty.Map(cty.String)},
+								IsOptional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName      string
+		bodySchema    *schema.BodySchema
+		cfg           string
+		pos           hcl.Pos
+		expectedNames []string
+	}{
+		{
+			"nil schema",
+			nil,
+			`resource "aws_instance" "foo" {
+}
+`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			[]string{},
+		},
+		{
+			"root body",
+			bodySchema,
+			`resource "aws_instance" "foo" {
+}
+`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 34},
+			[]string{"top_attr"},
+		},
+		{
+			"partially filled dependent block",
+			bodySchema,
+			`resource "aws_instance" "foo" {
+  ami = "ami-1234"
+
+}
+`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 51},
+			[]string{"instance_type", "name"},
+		},
+		{
+			"block without dependent body",
+			bodySchema,
+			`resource "unknown" "foo" {
+  count = 1
+
+}
+`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 39},
+			[]string{"name"},
+		},
+		{
+			"all required set",
+			bodySchema,
+			`resource "aws_instance" "foo" {
+  ami           = "ami-1234"
+  instance_type = "t2.micro"
+  name          = "foo"
+
+}
+`,
+			hcl.Pos{Line: 5, Column: 1, Byte: 114},
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: tc.bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			names, err := d.MissingRequiredAttributes("test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedNames, names); diff != "" {
+				t.Fatalf("unexpected names: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type schemaDump struct {
+	BlockPath    []blockDump                `json:"block_path"`
+	Attributes   map[string]attributeDump   `json:"attributes"`
+	AnyAttribute *attributeDump             `json:"any_attribute,omitempty"`
+	Blocks       map[string]blockSchemaDump `json:"blocks"`
+	Attribute    *namedAttributeDump        `json:"attribute,omitempty"`
+}
+
+type blockDump struct {
+	Type   string   `json:"type"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+type attributeDump struct {
+	Constraint   constraintDump `json:"constraint"`
+	IsRequired   bool           `json:"is_required,omitempty"`
+	IsOptional   bool           `json:"is_optional,omitempty"`
+	IsComputed   bool           `json:"is_computed,omitempty"`
+	IsDeprecated bool           `json:"is_deprecated,omitempty"`
+	IsDepKey     bool           `json:"is_dep_key,omitempty"`
+}
+
+type namedAttributeDump struct {
+	Name string `json:"name"`
+	attributeDump
+}
+
+type constraintDump struct {
+	Type         string `json:"type"`
+	FriendlyName string `json:"friendly_name,omitempty"`
+}
+
+type blockSchemaDump struct {
+	Labels   []string `json:"labels,omitempty"`
+	MinItems uint64   `json:"min_items,omitempty"`
+	MaxItems uint64   `json:"max_items,omitempty"`
+}
+
+// SchemaDumpAtPos serializes the schema context resolved at the given
+// position into JSON, for debugging and tooling purposes.
+//
+// The dump describes the path of blocks enclosing the position,
+// attributes and blocks of the innermost body (resolved through any
+// dependent body schemas and body extensions, as in completion)
+// and the attribute at the position, if any.
+func (d *PathDecoder) SchemaDumpAtPos(filename string, pos hcl.Pos) ([]byte, error) {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	rootBody, err := d.bodyForFileAndPos(filename, f, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pathCtx.Schema == nil {
+		return nil, &NoSchemaError{}
+	}
+
+	dump := schemaDump{
+		BlockPath:  make([]blockDump, 0),
+		Attributes: make(map[string]attributeDump, 0),
+		Blocks:     make(map[string]blockSchemaDump, 0),
+	}
+
+	body, bodySchema := d.schemaDumpInBody(&dump, rootBody, d.pathCtx.Schema, pos)
+	if bodySchema == nil {
+		return json.Marshal(dump)
+	}
+
+	for name, attr := range bodySchema.Attributes {
+		dump.Attributes[name] = attributeToDump(attr)
+	}
+	for name, attr := range extensionAttributes(bodySchema.Extensions) {
+		dump.Attributes[name] = attributeToDump(attr)
+	}
+	if bodySchema.AnyAttribute != nil {
+		anyAttr := attributeToDump(bodySchema.AnyAttribute)
+		dump.AnyAttribute = &anyAttr
+	}
+	for bType, block := range bodySchema.Blocks {
+		labels := make([]string, 0, len(block.Labels))
+		for _, label := range block.Labels {
+			labels = append(labels, label.Name)
+		}
+		dump.Blocks[bType] = blockSchemaDump{
+			Labels:   labels,
+			MinItems: block.MinItems,
+			MaxItems: block.MaxItems,
+		}
+	}
+
+	for name, attr := range body.Attributes {
+		if !attr.Range().ContainsPos(pos) {
+			continue
+		}
+		if aSchema, ok := attributeSchemaForName(bodySchema, name); ok {
+			dump.Attribute = &namedAttributeDump{
+				Name:          name,
+				attributeDump: attributeToDump(aSchema),
+			}
+		}
+	}
+
+	return json.Marshal(dump)
+}
+
+// schemaDumpInBody records the path of blocks enclosing the position
+// and returns the innermost body along with its (merged) schema
+func (d *PathDecoder) schemaDumpInBody(dump *schemaDump, body *hclsyntax.Body, bodySchema *schema.BodySchema, pos hcl.Pos) (*hclsyntax.Body, *schema.BodySchema) {
+	for _, block := range body.Blocks {
+		if block.Body == nil || !block.Body.Range().ContainsPos(pos) {
+			continue
+		}
+
+		dump.BlockPath = append(dump.BlockPath, blockDump{
+			Type:   block.Type,
+			Labels: block.Labels,
+		})
+
+		blockSchema, ok := bodySchema.Blocks[block.Type]
+		if !ok {
+			return block.Body, nil
+		}
+
+		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+		return d.schemaDumpInBody(dump, block.Body, mergedSchema, pos)
+	}
+
+	return body, bodySchema
+}
+
+// extensionAttributes returns schemas of attributes
+// enabled in the body via the given extensions
+func extensionAttributes(ext *schema.BodyExtensions) map[string]*schema.AttributeSchema {
+	attrs := make(map[string]*schema.AttributeSchema, 0)
+	if ext == nil {
+		return attrs
+	}
+	if ext.Count {
+		attrs["count"] = schemahelper.CountAttributeSchema()
+	}
+	if ext.ForEach {
+		attrs["for_each"] = schemahelper.ForEachAttributeSchema()
+	}
+	if ext.DependsOn {
+		attrs["depends_on"] = schemahelper.DependsOnAttributeSchema(ext.DependsOnScopeIds)
+	}
+	return attrs
+}
+
+// attributeSchemaForName returns the schema of the named attribute,
+// preferring extensions and falling back to AnyAttribute, as completion
+// and hover do
+func attributeSchemaForName(bodySchema *schema.BodySchema, name string) (*schema.AttributeSchema, bool) {
+	if aSchema, ok := extensionAttributes(bodySchema.Extensions)[name]; ok {
+		return aSchema, true
+	}
+	if aSchema, ok := bodySchema.Attributes[name]; ok {
+		return aSchema, true
+	}
+	if bodySchema.AnyAttribute != nil {
+		return bodySchema.AnyAttribute, true
+	}
+	return nil, false
+}
+
+func attributeToDump(attr *schema.AttributeSchema) attributeDump {
+	cons := constraintDump{}
+	if attr.Constraint != nil {
+		cons = constraintDump{
+			Type:         fmt.Sprintf("%T", attr.Constraint),
+			FriendlyName: attr.Constraint.FriendlyName(),
+		}
+	}
+
+	return attributeDump{
+		Constraint:   cons,
+		IsRequired:   attr.IsRequired,
+		IsOptional:   attr.IsOptional,
+		IsComputed:   attr.IsComputed,
+		IsDeprecated: attr.IsDeprecated,
+		IsDepKey:     attr.IsDepKey,
+	}
+}
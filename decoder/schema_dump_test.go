@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSchemaDumpAtPos(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type", IsDepKey: true},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+							IsOptional: true,
+						},
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "aws_instance"},
+						},
+					}): {
+						Blocks: map[string]*schema.BlockSchema{
+							"ebs": {
+								Body: &schema.BodySchema{
+									Attributes: map[string]*schema.AttributeSchema{
+										"size": {
+											Constraint: schema.LiteralType{Type: cty.Number},
+											IsRequired: true,
+										},
+									},
+								},
+								MaxItems: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`resource "aws_instance" "foo" {
+  ebs {
+    size = 10
+  }
+}
+`)
+	f, _ := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	testCases := []struct {
+		testName     string
+		pos          hcl.Pos
+		expectedDump string
+	}{
+		{
+			"root body",
+			hcl.InitialPos,
+			`{"block_path":[],"attributes":{},"blocks":{"resource":{"labels":["type","name"]}}}`,
+		},
+		{
+			"dependent body",
+			hcl.Pos{Line: 2, Column: 3, Byte: 34},
+			`{"block_path":[{"type":"resource","labels":["aws_instance","foo"]}],` +
+				`"attributes":{"count":{"constraint":{"type":"schema.LiteralType","friendly_name":"number"},"is_optional":true}},` +
+				`"blocks":{"ebs":{"max_items":1}}}`,
+		},
+		{
+			"nested attribute",
+			hcl.Pos{Line: 3, Column: 7, Byte: 46},
+			`{"block_path":[{"type":"resource","labels":["aws_instance","foo"]},{"type":"ebs"}],` +
+				`"attributes":{"size":{"constraint":{"type":"schema.LiteralType","friendly_name":"number"},"is_required":true}},` +
+				`"blocks":{},` +
+				`"attribute":{"name":"size","constraint":{"type":"schema.LiteralType","friendly_name":"number"},"is_required":true}}`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			dump, err := d.SchemaDumpAtPos("test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedDump, string(dump)); diff != "" {
+				t.Fatalf("unexpected dump: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSchemaDumpAtPos_extensionsAndAnyAttribute(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type", IsDepKey: true},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Count: true,
+					},
+					AnyAttribute: &schema.AttributeSchema{
+						Constraint: schema.LiteralType{Type: cty.String},
+						IsOptional: true,
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "aws_instance"},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"ami": {
+								Constraint: schema.LiteralType{Type: cty.String},
+								IsRequired: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`resource "aws_instance" "foo" {
+  count = 1
+  ami = "x"
+  tag = "x"
+}
+`)
+	f, _ := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	bodyDump := `{"block_path":[{"type":"resource","labels":["aws_instance","foo"]}],` +
+		`"attributes":{"ami":{"constraint":{"type":"schema.LiteralType","friendly_name":"string"},"is_required":true},` +
+		`"count":{"constraint":{"type":"schema.AnyExpression","friendly_name":"number"},"is_optional":true}},` +
+		`"any_attribute":{"constraint":{"type":"schema.LiteralType","friendly_name":"string"},"is_optional":true},` +
+		`"blocks":{},`
+
+	testCases := []struct {
+		testName     string
+		pos          hcl.Pos
+		expectedDump string
+	}{
+		{
+			"extension attribute",
+			hcl.Pos{Line: 2, Column: 4, Byte: 35},
+			bodyDump + `"attribute":{"name":"count","constraint":{"type":"schema.AnyExpression","friendly_name":"number"},"is_optional":true}}`,
+		},
+		{
+			"dependent attribute",
+			hcl.Pos{Line: 3, Column: 4, Byte: 47},
+			bodyDump + `"attribute":{"name":"ami","constraint":{"type":"schema.LiteralType","friendly_name":"string"},"is_required":true}}`,
+		},
+		{
+			"any attribute",
+			hcl.Pos{Line: 4, Column: 4, Byte: 59},
+			bodyDump + `"attribute":{"name":"tag","constraint":{"type":"schema.LiteralType","friendly_name":"string"},"is_optional":true}}`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			dump, err := d.SchemaDumpAtPos("test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedDump, string(dump)); diff != "" {
+				t.Fatalf("unexpected dump: %s", diff)
+			}
+		})
+	}
+}
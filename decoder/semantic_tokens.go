@@ -14,25 +14,39 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+// SemanticTokenLegend returns the ordered token types and modifiers
+// which the decoder may emit, for clients to register (e.g. as the LSP
+// semantic tokens legend). The ordering is stable, such that the index
+// of a type or modifier can be used for encoding.
+//
+// Modifiers declared via schema (SemanticTokenModifiers) are not included.
+func (d *Decoder) SemanticTokenLegend() (types []string, modifiers []string) {
+	types = make([]string, len(lang.SupportedSemanticTokenTypes))
+	for i, tokenType := range lang.SupportedSemanticTokenTypes {
+		types[i] = string(tokenType)
+	}
+
+	modifiers = make([]string, len(lang.SupportedSemanticTokenModifiers))
+	for i, modifier := range lang.SupportedSemanticTokenModifiers {
+		modifiers[i] = string(modifier)
+	}
+
+	return types, modifiers
+}
+
 // SemanticTokensInFile returns a sequence of semantic tokens
 // within the config file.
 func (d *PathDecoder) SemanticTokensInFile(ctx context.Context, filename string) ([]lang.SemanticToken, error) {
-	f, err := d.fileByName(filename)
-	if err != nil {
-		return nil, err
-	}
+	tokens := make([]lang.SemanticToken, 0)
 
-	body, err := d.bodyForFileAndPos(filename, f, hcl.InitialPos)
+	err := d.SemanticTokensInFileFunc(ctx, filename, func(token lang.SemanticToken) error {
+		tokens = append(tokens, token)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if d.pathCtx.Schema == nil {
-		return []lang.SemanticToken{}, nil
-	}
-
-	tokens := d.tokensForBody(ctx, body, d.pathCtx.Schema, []lang.SemanticTokenModifier{})
-
 	// TODO decouple semantic tokens for valid references from AST walking
 	//   instead of matching targets and origins when encountering a traversal expression,
 	//   we can do this way earlier by comparing pathCtx.ReferenceTargets and
@@ -46,87 +60,190 @@ func (d *PathDecoder) SemanticTokensInFile(ctx context.Context, filename string)
 	return tokens, nil
 }
 
-func (d *PathDecoder) tokensForBody(ctx context.Context, body *hclsyntax.Body, bodySchema *schema.BodySchema, parentModifiers []lang.SemanticTokenModifier) []lang.SemanticToken {
-	tokens := make([]lang.SemanticToken, 0)
+// SemanticTokensInFileFunc calls fn for each semantic token
+// within the config file as it is produced, which allows
+// the caller to stream tokens without buffering them.
+//
+// Attributes and blocks are walked in source order, such that
+// tokens can be encoded as they arrive.
+// Any error returned from fn stops the walk and is returned.
+func (d *PathDecoder) SemanticTokensInFileFunc(ctx context.Context, filename string, fn func(lang.SemanticToken) error) error {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return err
+	}
 
+	body, err := d.bodyForFileAndPos(filename, f, hcl.InitialPos)
+	if err != nil {
+		return err
+	}
+
+	if d.pathCtx.Schema == nil {
+		return nil
+	}
+
+	return d.tokensForBody(ctx, body, d.pathCtx.Schema, []lang.SemanticTokenModifier{}, fn)
+}
+
+func (d *PathDecoder) tokensForBody(ctx context.Context, body *hclsyntax.Body, bodySchema *schema.BodySchema, parentModifiers []lang.SemanticTokenModifier, fn func(lang.SemanticToken) error) error {
 	if bodySchema == nil {
-		return tokens
-	}
-
-	for name, attr := range body.Attributes {
-		attrSchema, ok := bodySchema.Attributes[name]
-		if !ok {
-			if bodySchema.Extensions != nil && name == "count" && bodySchema.Extensions.Count {
-				attrSchema = schemahelper.CountAttributeSchema()
-			} else if bodySchema.Extensions != nil && name == "for_each" && bodySchema.Extensions.ForEach {
-				attrSchema = schemahelper.ForEachAttributeSchema()
-			} else {
-				if bodySchema.AnyAttribute == nil {
-					// unknown attribute
-					continue
-				}
-				attrSchema = bodySchema.AnyAttribute
+		return nil
+	}
+
+	for _, node := range bodyNodesInSourceOrder(body) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var err error
+		switch n := node.(type) {
+		case *hclsyntax.Attribute:
+			err = d.tokensForAttribute(ctx, n, bodySchema, parentModifiers, fn)
+		case *hclsyntax.Block:
+			err = d.tokensForBlock(ctx, n, bodySchema, parentModifiers, fn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bodyNodesInSourceOrder returns attributes and blocks of the body
+// ordered by their position in the file
+func bodyNodesInSourceOrder(body *hclsyntax.Body) []hclsyntax.Node {
+	nodes := make([]hclsyntax.Node, 0, len(body.Attributes)+len(body.Blocks))
+	for _, attr := range body.Attributes {
+		nodes = append(nodes, attr)
+	}
+	for _, block := range body.Blocks {
+		nodes = append(nodes, block)
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Range().Start.Byte < nodes[j].Range().Start.Byte
+	})
+
+	return nodes
+}
+
+func (d *PathDecoder) tokensForAttribute(ctx context.Context, attr *hclsyntax.Attribute, bodySchema *schema.BodySchema, parentModifiers []lang.SemanticTokenModifier, fn func(lang.SemanticToken) error) error {
+	name := attr.Name
+	attrSchema, ok := bodySchema.Attributes[name]
+	if !ok {
+		if bodySchema.Extensions != nil && name == "count" && bodySchema.Extensions.Count {
+			attrSchema = schemahelper.CountAttributeSchema()
+		} else if bodySchema.Extensions != nil && name == "for_each" && bodySchema.Extensions.ForEach {
+			attrSchema = schemahelper.ForEachAttributeSchema()
+		} else if bodySchema.Extensions != nil && name == "depends_on" && bodySchema.Extensions.DependsOn {
+			attrSchema = schemahelper.DependsOnAttributeSchema(bodySchema.Extensions.DependsOnScopeIds)
+		} else {
+			if bodySchema.AnyAttribute == nil {
+				// unknown attribute
+				return nil
 			}
+			attrSchema = bodySchema.AnyAttribute
 		}
+	}
 
-		attrModifiers := make([]lang.SemanticTokenModifier, 0)
-		attrModifiers = append(attrModifiers, parentModifiers...)
-		attrModifiers = append(attrModifiers, attrSchema.SemanticTokenModifiers...)
+	attrModifiers := make([]lang.SemanticTokenModifier, 0)
+	attrModifiers = append(attrModifiers, parentModifiers...)
+	attrModifiers = append(attrModifiers, attrSchema.SemanticTokenModifiers...)
 
-		tokens = append(tokens, lang.SemanticToken{
-			Type:      lang.TokenAttrName,
-			Modifiers: attrModifiers,
-			Range:     attr.NameRange,
-		})
+	attrNameModifiers := attrModifiers
+	if attrSchema.IsDeprecated {
+		attrNameModifiers = withTokenModifier(attrModifiers, lang.TokenModifierDeprecated)
+	}
 
-		tokens = append(tokens, d.newExpression(attr.Expr, attrSchema.Constraint).SemanticTokens(ctx)...)
+	err := fn(lang.SemanticToken{
+		Type:      lang.TokenAttrName,
+		Modifiers: attrNameModifiers,
+		Range:     attr.NameRange,
+	})
+	if err != nil {
+		return err
 	}
 
-	for _, block := range body.Blocks {
-		blockSchema, hasDepSchema := bodySchema.Blocks[block.Type]
-		if !hasDepSchema {
-			// unknown block
-			continue
+	for _, token := range d.newExpression(attr.Expr, attrSchema.Constraint).SemanticTokens(ctx) {
+		if err := fn(token); err != nil {
+			return err
 		}
+	}
 
-		blockModifiers := make([]lang.SemanticTokenModifier, 0)
-		blockModifiers = append(blockModifiers, parentModifiers...)
-		blockModifiers = append(blockModifiers, blockSchema.SemanticTokenModifiers...)
+	return nil
+}
 
-		tokens = append(tokens, lang.SemanticToken{
-			Type:      lang.TokenBlockType,
-			Modifiers: blockModifiers,
-			Range:     block.TypeRange,
-		})
+func (d *PathDecoder) tokensForBlock(ctx context.Context, block *hclsyntax.Block, bodySchema *schema.BodySchema, parentModifiers []lang.SemanticTokenModifier, fn func(lang.SemanticToken) error) error {
+	blockSchema, hasDepSchema := bodySchema.Blocks[block.Type]
+	if !hasDepSchema {
+		// unknown block
+		return nil
+	}
 
-		for i, labelRange := range block.LabelRanges {
-			if i+1 > len(blockSchema.Labels) {
-				// unknown label
-				continue
-			}
+	blockModifiers := make([]lang.SemanticTokenModifier, 0)
+	blockModifiers = append(blockModifiers, parentModifiers...)
+	blockModifiers = append(blockModifiers, blockSchema.SemanticTokenModifiers...)
+
+	// deprecation is only reflected on the block type itself,
+	// not inherited by labels or nested tokens
+	blockTypeModifiers := blockModifiers
+	if blockSchema.IsDeprecated {
+		blockTypeModifiers = withTokenModifier(blockModifiers, lang.TokenModifierDeprecated)
+	}
+
+	err := fn(lang.SemanticToken{
+		Type:      lang.TokenBlockType,
+		Modifiers: blockTypeModifiers,
+		Range:     block.TypeRange,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, labelRange := range block.LabelRanges {
+		if i+1 > len(blockSchema.Labels) {
+			// unknown label
+			continue
+		}
 
-			labelSchema := blockSchema.Labels[i]
+		labelSchema := blockSchema.Labels[i]
 
-			labelModifiers := make([]lang.SemanticTokenModifier, 0)
-			labelModifiers = append(labelModifiers, parentModifiers...)
-			labelModifiers = append(labelModifiers, blockSchema.SemanticTokenModifiers...)
-			labelModifiers = append(labelModifiers, labelSchema.SemanticTokenModifiers...)
+		labelModifiers := make([]lang.SemanticTokenModifier, 0)
+		labelModifiers = append(labelModifiers, parentModifiers...)
+		labelModifiers = append(labelModifiers, blockSchema.SemanticTokenModifiers...)
+		labelModifiers = append(labelModifiers, labelSchema.SemanticTokenModifiers...)
 
-			tokens = append(tokens, lang.SemanticToken{
-				Type:      lang.TokenBlockLabel,
-				Modifiers: labelModifiers,
-				Range:     labelRange,
-			})
+		tokenRange := labelRange
+		if labelSchema.IsDepKey {
+			// dependency keys (e.g. resource type) are distinguished
+			// from other labels (e.g. resource name)
+			if !hasTokenModifier(labelModifiers, lang.TokenModifierDependent) {
+				labelModifiers = append(labelModifiers, lang.TokenModifierDependent)
+			}
+			tokenRange = d.labelContentRange(labelRange)
 		}
 
-		if block.Body != nil {
-			mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+		err := fn(lang.SemanticToken{
+			Type:      lang.TokenBlockLabel,
+			Modifiers: labelModifiers,
+			Range:     tokenRange,
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-			tokens = append(tokens, d.tokensForBody(ctx, block.Body, mergedSchema, blockModifiers)...)
+	if block.Body != nil {
+		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+
+		err := d.tokensForBody(ctx, block.Body, mergedSchema, blockModifiers, fn)
+		if err != nil {
+			return err
 		}
 	}
 
-	return tokens
+	return nil
 }
 
 func isPrimitiveTypeDeclaration(kw string) bool {
@@ -144,3 +261,50 @@ func isPrimitiveTypeDeclaration(kw string) bool {
 	}
 	return false
 }
+
+// labelContentRange returns the range of the label content
+// without the surrounding quotes, if the label is quoted
+func (d *PathDecoder) labelContentRange(rng hcl.Range) hcl.Range {
+	src, err := d.bytesForFile(rng.Filename)
+	if err != nil || rng.End.Byte > len(src) || rng.End.Byte-rng.Start.Byte < 2 {
+		return rng
+	}
+	if src[rng.Start.Byte] != '"' || src[rng.End.Byte-1] != '"' {
+		return rng
+	}
+
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start: hcl.Pos{
+			Line:   rng.Start.Line,
+			Column: rng.Start.Column + 1,
+			Byte:   rng.Start.Byte + 1,
+		},
+		End: hcl.Pos{
+			Line:   rng.End.Line,
+			Column: rng.End.Column - 1,
+			Byte:   rng.End.Byte - 1,
+		},
+	}
+}
+
+func hasTokenModifier(modifiers []lang.SemanticTokenModifier, modifier lang.SemanticTokenModifier) bool {
+	for _, m := range modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+// withTokenModifier returns a copy of modifiers with the given modifier
+// appended, unless it is already present
+func withTokenModifier(modifiers []lang.SemanticTokenModifier, modifier lang.SemanticTokenModifier) []lang.SemanticTokenModifier {
+	if hasTokenModifier(modifiers, modifier) {
+		return modifiers
+	}
+
+	newModifiers := make([]lang.SemanticTokenModifier, 0, len(modifiers)+1)
+	newModifiers = append(newModifiers, modifiers...)
+	return append(newModifiers, modifier)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/hcl/v2/json"
 	"github.com/zclconf/go-cty-debug/ctydebug"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 )
 
 func TestDecoder_SemanticTokensInFile_emptyBody(t *testing.T) {
@@ -64,6 +65,134 @@ func TestDecoder_SemanticTokensInFile_json(t *testing.T) {
 	}
 }
 
+func TestDecoder_SemanticTokensInFileFunc(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"attr": {Constraint: schema.LiteralType{Type: cty.Number}},
+					},
+				},
+			},
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte(`myblock "foo" {
+  attr = 42
+}
+`), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+
+	tokens := make([]lang.SemanticToken, 0)
+	err := d.SemanticTokensInFileFunc(ctx, "test.tf", func(token lang.SemanticToken) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expectedTokens) != 4 {
+		t.Fatalf("expected 4 tokens, given: %#v", expectedTokens)
+	}
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+
+	stopErr := errors.New("stop")
+	calls := 0
+	err = d.SemanticTokensInFileFunc(ctx, "test.tf", func(token lang.SemanticToken) error {
+		calls++
+		return stopErr
+	})
+	if !errors.Is(err, stopErr) {
+		t.Fatalf("expected error to be propagated, given: %#v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected walk to stop after first token, %d calls made", calls)
+	}
+}
+
+func TestDecoder_SemanticTokensInFileFunc_sourceOrder(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"one":   {Constraint: schema.LiteralType{Type: cty.Number}},
+			"two":   {Constraint: schema.LiteralType{Type: cty.Number}},
+			"three": {Constraint: schema.LiteralType{Type: cty.Number}},
+			"four":  {Constraint: schema.LiteralType{Type: cty.Number}},
+			"five":  {Constraint: schema.LiteralType{Type: cty.Number}},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"six":   {Constraint: schema.LiteralType{Type: cty.Number}},
+						"seven": {Constraint: schema.LiteralType{Type: cty.Number}},
+						"eight": {Constraint: schema.LiteralType{Type: cty.Number}},
+					},
+				},
+			},
+		},
+	}
+	f, _ := hclsyntax.ParseConfig([]byte(`five = 5
+myblock {
+  eight = 8
+  six = 6
+  seven = 7
+}
+one = 1
+four = 4
+myblock {}
+two = 2
+three = 3
+`), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+
+	// attributes are stored in a map, so repeat
+	// to make any map-ordered walk likely to surface
+	for i := 0; i < 10; i++ {
+		tokens := make([]lang.SemanticToken, 0)
+		err := d.SemanticTokensInFileFunc(ctx, "test.tf", func(token lang.SemanticToken) error {
+			tokens = append(tokens, token)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tokens) != 18 {
+			t.Fatalf("expected 18 tokens, given %d: %#v", len(tokens), tokens)
+		}
+
+		for j := 1; j < len(tokens); j++ {
+			if tokens[j].Range.Start.Byte < tokens[j-1].Range.Start.Byte {
+				t.Fatalf("token %d (%s) streamed after token %d (%s)",
+					j, tokens[j].Range, j-1, tokens[j-1].Range)
+			}
+		}
+	}
+}
+
 func TestDecoder_SemanticTokensInFile_zeroByteContent(t *testing.T) {
 	f, pDiags := hclsyntax.ParseConfig([]byte{}, "test.tf", hcl.InitialPos)
 	if len(pDiags) > 0 {
@@ -193,6 +322,7 @@ resource "vault_auth_backend" "blah" {
 			Type: lang.TokenAttrName,
 			Modifiers: []lang.SemanticTokenModifier{
 				lang.TokenModifierDependent,
+				lang.TokenModifierDeprecated,
 			},
 			Range: hcl.Range{
 				Filename: "test.tf",
@@ -285,13 +415,13 @@ resource "vault_auth_backend" "blah" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   5,
-					Column: 10,
-					Byte:   58,
+					Column: 11,
+					Byte:   59,
 				},
 				End: hcl.Pos{
 					Line:   5,
-					Column: 30,
-					Byte:   78,
+					Column: 29,
+					Byte:   77,
 				},
 			},
 		},
@@ -368,6 +498,231 @@ func TestDecoder_SemanticTokensInFile_nil_expr(t *testing.T) {
 	}
 }
 
+func TestDecoder_SemanticTokensInFile_deprecated(t *testing.T) {
+	f, _ := hclsyntax.ParseConfig([]byte(`legacy {
+  attr = 1
+}
+`), "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: &schema.BodySchema{
+			Blocks: map[string]*schema.BlockSchema{
+				"legacy": {
+					IsDeprecated: true,
+					Body: &schema.BodySchema{
+						Attributes: map[string]*schema.AttributeSchema{
+							"attr": {Constraint: schema.LiteralType{Type: cty.Number}},
+						},
+					},
+				},
+			},
+		},
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{
+			Type: lang.TokenBlockType,
+			Modifiers: lang.SemanticTokenModifiers{
+				lang.TokenModifierDeprecated,
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 7, Byte: 6},
+			},
+		},
+		{
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
+				End:      hcl.Pos{Line: 2, Column: 7, Byte: 15},
+			},
+		},
+		{
+			Type:      lang.TokenNumber,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 10, Byte: 18},
+				End:      hcl.Pos{Line: 2, Column: 11, Byte: 19},
+			},
+		},
+	}
+
+	diff := cmp.Diff(expectedTokens, tokens)
+	if diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokenLegend(t *testing.T) {
+	d := NewDecoder(&testPathReader{})
+
+	types, modifiers := d.SemanticTokenLegend()
+
+	expectedTypes := []string{
+		"hcl-attrName",
+		"hcl-blockType",
+		"hcl-blockLabel",
+		"hcl-bool",
+		"hcl-string",
+		"hcl-number",
+		"hcl-objectKey",
+		"hcl-mapKey",
+		"hcl-keyword",
+		"hcl-referenceStep",
+		"hcl-typeComplex",
+		"hcl-typePrimitive",
+		"hcl-functionName",
+	}
+	if diff := cmp.Diff(expectedTypes, types); diff != "" {
+		t.Fatalf("unexpected token types: %s", diff)
+	}
+
+	expectedModifiers := []string{
+		"hcl-dependent",
+		"deprecated",
+	}
+	if diff := cmp.Diff(expectedModifiers, modifiers); diff != "" {
+		t.Fatalf("unexpected token modifiers: %s", diff)
+	}
+
+	// every token emitted by the decoder must be covered by the legend
+	f, _ := hclsyntax.ParseConfig([]byte(`resource "aws_instance" "foo" {
+  enabled = true
+  name    = "foo"
+  size    = 1
+  tags    = { env = "prod" }
+  obj     = { key = 1 }
+  mode    = fast
+  ref     = var.foo
+  typ     = list(string)
+  fn      = upper("foo")
+}
+`), "test.tf", hcl.InitialPos)
+
+	pd := testPathDecoder(t, &PathContext{
+		Schema: &schema.BodySchema{
+			Blocks: map[string]*schema.BlockSchema{
+				"resource": {
+					Labels: []*schema.LabelSchema{
+						{Name: "type", IsDepKey: true},
+						{Name: "name"},
+					},
+					DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+						schema.NewSchemaKey(schema.DependencyKeys{
+							Labels: []schema.LabelDependent{
+								{Index: 0, Value: "aws_instance"},
+							},
+						}): {
+							Attributes: map[string]*schema.AttributeSchema{
+								"enabled": {
+									Constraint:   schema.LiteralType{Type: cty.Bool},
+									IsDeprecated: true,
+								},
+								"name": {Constraint: schema.LiteralType{Type: cty.String}},
+								"size": {Constraint: schema.LiteralType{Type: cty.Number}},
+								"tags": {Constraint: schema.Map{Elem: schema.LiteralType{Type: cty.String}}},
+								"obj": {Constraint: schema.Object{
+									Attributes: schema.ObjectAttributes{
+										"key": {Constraint: schema.LiteralType{Type: cty.Number}},
+									},
+								}},
+								"mode": {Constraint: schema.Keyword{Keyword: "fast"}},
+								"ref":  {Constraint: schema.Reference{OfType: cty.String}},
+								"typ":  {Constraint: schema.TypeDeclaration{}},
+								"fn":   {Constraint: schema.AnyExpression{OfType: cty.String}},
+							},
+						},
+					},
+				},
+			},
+		},
+		ReferenceOrigins: reference.Origins{
+			reference.LocalOrigin{
+				Addr: lang.Address{
+					lang.RootStep{Name: "var"},
+					lang.AttrStep{Name: "foo"},
+				},
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 8, Column: 13, Byte: 163},
+					End:      hcl.Pos{Line: 8, Column: 20, Byte: 170},
+				},
+				Constraints: reference.OriginConstraints{
+					{OfType: cty.String},
+				},
+			},
+		},
+		ReferenceTargets: reference.Targets{
+			{
+				Addr: lang.Address{
+					lang.RootStep{Name: "var"},
+					lang.AttrStep{Name: "foo"},
+				},
+				Type: cty.String,
+			},
+		},
+		Functions: map[string]schema.FunctionSignature{
+			"upper": {
+				Params: []function.Parameter{
+					{Name: "str", Type: cty.String},
+				},
+				ReturnType: cty.String,
+			},
+		},
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	tokens, err := pd.SemanticTokensInFile(context.Background(), "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emittedTypes := make(map[string]bool)
+	emittedModifiers := make(map[string]bool)
+	for _, token := range tokens {
+		emittedTypes[string(token.Type)] = true
+		for _, modifier := range token.Modifiers {
+			emittedModifiers[string(modifier)] = true
+		}
+	}
+
+	for _, tokenType := range types {
+		if !emittedTypes[tokenType] {
+			t.Errorf("expected token type %q to be emitted", tokenType)
+		}
+		delete(emittedTypes, tokenType)
+	}
+	for tokenType := range emittedTypes {
+		t.Errorf("emitted token type %q missing from legend", tokenType)
+	}
+
+	for _, modifier := range modifiers {
+		if !emittedModifiers[modifier] {
+			t.Errorf("expected token modifier %q to be emitted", modifier)
+		}
+		delete(emittedModifiers, modifier)
+	}
+	for modifier := range emittedModifiers {
+		t.Errorf("emitted token modifier %q missing from legend", modifier)
+	}
+}
+
 func TestDecoder_SemanticTokensInFile_dependentSchema(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
@@ -460,13 +815,13 @@ resource "aws_instance" "beta" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   1,
-					Column: 10,
-					Byte:   9,
+					Column: 11,
+					Byte:   10,
 				},
 				End: hcl.Pos{
 					Line:   1,
-					Column: 30,
-					Byte:   29,
+					Column: 29,
+					Byte:   28,
 				},
 			},
 		},
@@ -513,13 +868,13 @@ resource "aws_instance" "beta" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   4,
-					Column: 10,
-					Byte:   83,
+					Column: 11,
+					Byte:   84,
 				},
 				End: hcl.Pos{
 					Line:   4,
-					Column: 24,
-					Byte:   97,
+					Column: 23,
+					Byte:   96,
 				},
 			},
 		},
@@ -745,6 +1100,7 @@ resource "vault_auth_backend" "blah" {
 			Modifiers: []lang.SemanticTokenModifier{
 				lang.SemanticTokenModifier("module"),
 				lang.TokenModifierDependent,
+				lang.TokenModifierDeprecated,
 			},
 			Range: hcl.Range{
 				Filename: "test.tf",
@@ -843,13 +1199,13 @@ resource "vault_auth_backend" "blah" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   5,
-					Column: 10,
-					Byte:   58,
+					Column: 11,
+					Byte:   59,
 				},
 				End: hcl.Pos{
 					Line:   5,
-					Column: 30,
-					Byte:   78,
+					Column: 29,
+					Byte:   77,
 				},
 			},
 		},
@@ -1025,13 +1381,13 @@ resource "aws_instance" "app_server" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 10,
-					Byte:   10,
+					Column: 11,
+					Byte:   11,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 24,
-					Byte:   24,
+					Column: 23,
+					Byte:   23,
 				},
 			},
 		},
@@ -1275,13 +1631,13 @@ resource "aws_instance" "app_server" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 10,
-					Byte:   10,
+					Column: 11,
+					Byte:   11,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 24,
-					Byte:   24,
+					Column: 23,
+					Byte:   23,
 				},
 			},
 		},
@@ -1475,13 +1831,13 @@ resource "vault_auth_backend" "blah" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 10,
-					Byte:   10,
+					Column: 11,
+					Byte:   11,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 30,
-					Byte:   30,
+					Column: 29,
+					Byte:   29,
 				},
 			},
 		},
@@ -1655,13 +2011,13 @@ resource "foobar" "name" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 10,
-					Byte:   10,
+					Column: 11,
+					Byte:   11,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 18,
-					Byte:   18,
+					Column: 17,
+					Byte:   17,
 				},
 			},
 		},
@@ -1887,8 +2243,8 @@ resource "foobar" "name" {
 			},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
-				End:      hcl.Pos{Line: 2, Column: 18, Byte: 18},
+				Start:    hcl.Pos{Line: 2, Column: 11, Byte: 11},
+				End:      hcl.Pos{Line: 2, Column: 17, Byte: 17},
 			},
 		},
 		{ // name
@@ -2057,8 +2413,8 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 					},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
-						End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 					},
 				},
 				{ // bar
@@ -2081,11 +2437,11 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "setting"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 32},
-						End:      hcl.Pos{Line: 2, Column: 20, Byte: 41},
+						Start:    hcl.Pos{Line: 2, Column: 12, Byte: 33},
+						End:      hcl.Pos{Line: 2, Column: 19, Byte: 40},
 					},
 				},
 				{ // content
@@ -2168,8 +2524,8 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 					},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
-						End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 					},
 				},
 				{ // bar
@@ -2192,11 +2548,11 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "setting"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 32},
-						End:      hcl.Pos{Line: 2, Column: 20, Byte: 41},
+						Start:    hcl.Pos{Line: 2, Column: 12, Byte: 33},
+						End:      hcl.Pos{Line: 2, Column: 19, Byte: 40},
 					},
 				},
 				{ // content
@@ -2219,11 +2575,11 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "setting"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 4, Column: 15, Byte: 72},
-						End:      hcl.Pos{Line: 4, Column: 20, Byte: 77},
+						Start:    hcl.Pos{Line: 4, Column: 16, Byte: 73},
+						End:      hcl.Pos{Line: 4, Column: 19, Byte: 76},
 					},
 				},
 				{ // content
@@ -2311,8 +2667,8 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 					},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
-						End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 					},
 				},
 				{ // "bar"
@@ -2353,11 +2709,11 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "bar"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
 					Range: hcl.Range{
 						Filename: "test.tf",
-						Start:    hcl.Pos{Line: 4, Column: 15, Byte: 58},
-						End:      hcl.Pos{Line: 4, Column: 20, Byte: 63},
+						Start:    hcl.Pos{Line: 4, Column: 16, Byte: 59},
+						End:      hcl.Pos{Line: 4, Column: 19, Byte: 62},
 					},
 				},
 			},
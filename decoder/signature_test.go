@@ -474,6 +474,114 @@ func TestSignatureAtPos(t *testing.T) {
 				ActiveParameter: 1,
 			},
 		},
+		{
+			"nested call, inner second parameter",
+			map[string]schema.FunctionSignature{
+				"join": {
+					Params: []function.Parameter{
+						{
+							Name:        "separator",
+							Type:        cty.String,
+							Description: "`separator` description",
+						},
+					},
+					VarParam: &function.Parameter{
+						Name:        "lists",
+						Type:        cty.List(cty.String),
+						Description: "`lists` description",
+					},
+					ReturnType:  cty.String,
+					Description: "`join` description",
+				},
+				"split": {
+					Params: []function.Parameter{
+						{
+							Name:        "separator",
+							Type:        cty.String,
+							Description: "`separator` description",
+						},
+						{
+							Name:        "str",
+							Type:        cty.String,
+							Description: "`str` description",
+						},
+					},
+					ReturnType:  cty.List(cty.String),
+					Description: "`split` description",
+				},
+			},
+			`x = join(",", split("-", ))`,
+			hcl.Pos{Line: 1, Column: 26, Byte: 25},
+			&lang.FunctionSignature{
+				Name:        "split(separator string, str string) list of string",
+				Description: lang.Markdown("`split` description"),
+				Parameters: []lang.FunctionParameter{
+					{
+						Name:        "separator",
+						Description: lang.Markdown("`separator` description"),
+					},
+					{
+						Name:        "str",
+						Description: lang.Markdown("`str` description"),
+					},
+				},
+				ActiveParameter: 1,
+			},
+		},
+		{
+			"nested call, outer variadic after inner call",
+			map[string]schema.FunctionSignature{
+				"join": {
+					Params: []function.Parameter{
+						{
+							Name:        "separator",
+							Type:        cty.String,
+							Description: "`separator` description",
+						},
+					},
+					VarParam: &function.Parameter{
+						Name:        "lists",
+						Type:        cty.List(cty.String),
+						Description: "`lists` description",
+					},
+					ReturnType:  cty.String,
+					Description: "`join` description",
+				},
+				"split": {
+					Params: []function.Parameter{
+						{
+							Name:        "separator",
+							Type:        cty.String,
+							Description: "`separator` description",
+						},
+						{
+							Name:        "str",
+							Type:        cty.String,
+							Description: "`str` description",
+						},
+					},
+					ReturnType:  cty.List(cty.String),
+					Description: "`split` description",
+				},
+			},
+			`x = join(",", split("-", "a"), )`,
+			hcl.Pos{Line: 1, Column: 32, Byte: 31},
+			&lang.FunctionSignature{
+				Name:        "join(separator string, …lists list of string) string",
+				Description: lang.Markdown("`join` description"),
+				Parameters: []lang.FunctionParameter{
+					{
+						Name:        "separator",
+						Description: lang.Markdown("`separator` description"),
+					},
+					{
+						Name:        "lists",
+						Description: lang.Markdown("`lists` description"),
+					},
+				},
+				ActiveParameter: 1,
+			},
+		},
 	}
 
 	for i, tc := range testCases {
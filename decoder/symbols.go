@@ -47,8 +47,9 @@ func (d *PathDecoder) symbolsInFile(filename string) ([]Symbol, error) {
 }
 
 // Symbols returns a hierarchy of symbols matching the query in all paths.
-// Query can be empty, as per LSP's workspace/symbol request,
-// in which case all symbols are returned.
+// Query is matched case-insensitively and can be empty,
+// as per LSP's workspace/symbol request, in which case
+// all symbols are returned.
 //
 // A symbol is typically represented by a block or an attribute.
 //
@@ -83,7 +84,7 @@ func (d *PathDecoder) symbols(query string) ([]Symbol, error) {
 		}
 
 		for _, symbol := range fSymbols {
-			if query == "" || strings.Contains(symbol.Name(), query) {
+			if query == "" || strings.Contains(strings.ToLower(symbol.Name()), strings.ToLower(query)) {
 				symbols = append(symbols, symbol)
 			}
 		}
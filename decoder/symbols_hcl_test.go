@@ -370,6 +370,85 @@ resource "aws_instance" "test" {
 	}
 }
 
+func TestDecoder_SymbolsInFile_hcl_nestedBlocks(t *testing.T) {
+	testCfg := []byte(`resource "aws_instance" "test" {
+  ebs_block_device {
+    size = 42
+  }
+}
+`)
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	dirPath := t.TempDir()
+	d, err := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	}).Path(lang.Path{Path: dirPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	symbols, err := d.SymbolsInFile("test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSymbols := []Symbol{
+		&BlockSymbol{
+			Type: "resource",
+			Labels: []string{
+				"aws_instance",
+				"test",
+			},
+			path: lang.Path{Path: dirPath},
+			rng: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 5, Column: 2, Byte: 73},
+			},
+			nestedSymbols: []Symbol{
+				&BlockSymbol{
+					Type: "ebs_block_device",
+					path: lang.Path{Path: dirPath},
+					rng: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 35},
+						End:      hcl.Pos{Line: 4, Column: 4, Byte: 71},
+					},
+					nestedSymbols: []Symbol{
+						&AttributeSymbol{
+							AttrName: "size",
+							ExprKind: lang.LiteralTypeKind{
+								Type: cty.Number,
+							},
+							path: lang.Path{Path: dirPath},
+							rng: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 3, Column: 5, Byte: 58},
+								End:      hcl.Pos{Line: 3, Column: 14, Byte: 67},
+							},
+							nestedSymbols: []Symbol{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	diff := cmp.Diff(expectedSymbols, symbols)
+	if diff != "" {
+		t.Fatalf("unexpected symbols:\n%s", diff)
+	}
+}
+
 func TestDecoder_SymbolsInFile_hcl_unknownExpression(t *testing.T) {
 	testCfg := []byte(`
 resource "aws_instance" "test" {
@@ -630,4 +709,14 @@ provider "google" {
 	if diff != "" {
 		t.Fatalf("unexpected symbols: %s", diff)
 	}
+
+	// query is case-insensitive
+	symbols, err = d.Symbols(context.Background(), "GooGLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff = cmp.Diff(expectedSymbols, symbols)
+	if diff != "" {
+		t.Fatalf("unexpected symbols for mixed-case query: %s", diff)
+	}
 }
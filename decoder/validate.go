@@ -6,6 +6,7 @@ package decoder
 import (
 	"context"
 
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/decoder/internal/walker"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
@@ -25,6 +26,8 @@ func (d *PathDecoder) Validate(ctx context.Context) (lang.DiagnosticsMap, error)
 		return diags, nil
 	}
 
+	ctx = schemahelper.WithDependentBodyCache(ctx)
+
 	// Validate module files per schema
 	for filename, f := range d.pathCtx.Files {
 		body, ok := f.Body.(*hclsyntax.Body)
@@ -61,6 +64,8 @@ func (d *PathDecoder) ValidateFile(ctx context.Context, filename string) (hcl.Di
 		return hcl.Diagnostics{}, &UnknownFileFormatError{Filename: filename}
 	}
 
+	ctx = schemahelper.WithDependentBodyCache(ctx)
+
 	return walker.Walk(ctx, body, d.pathCtx.Schema, validationWalker{
 		validators: d.pathCtx.Validators,
 	}), nil
@@ -66,7 +66,7 @@ func TestValidate_schema(t *testing.T) {
 						Subject: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 2, Column: 2, Byte: 10},
-							End:      hcl.Pos{Line: 2, Column: 9, Byte: 17},
+							End:      hcl.Pos{Line: 2, Column: 5, Byte: 13},
 						},
 					},
 				},
@@ -97,11 +97,11 @@ func TestValidate_schema(t *testing.T) {
 					&hcl.Diagnostic{
 						Severity: hcl.DiagError,
 						Summary:  "Unexpected attribute",
-						Detail:   "An attribute named \"foo\" is not expected here",
+						Detail:   "An attribute named \"foo\" is not expected in a \"foo\" block",
 						Subject: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 3, Column: 2, Byte: 17},
-							End:      hcl.Pos{Line: 3, Column: 9, Byte: 24},
+							End:      hcl.Pos{Line: 3, Column: 5, Byte: 20},
 						},
 					},
 				},
@@ -138,7 +138,7 @@ wakka = 2
 						Subject: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 9},
-							End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 14},
 						},
 					},
 				},
@@ -245,6 +245,190 @@ wakka = 2
 				},
 			},
 		},
+		{
+			"missing required attributes in nested and dependent bodies",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type", IsDepKey: true},
+						},
+						DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+							schema.NewSchemaKey(schema.DependencyKeys{
+								Labels: []schema.LabelDependent{
+									{Index: 0, Value: "aws_instance"},
+								},
+							}): {
+								Attributes: map[string]*schema.AttributeSchema{
+									"ami": {
+										IsRequired: true,
+										Constraint: schema.LiteralType{Type: cty.String},
+									},
+								},
+								Blocks: map[string]*schema.BlockSchema{
+									"nested": {
+										Body: &schema.BodySchema{
+											Attributes: map[string]*schema.AttributeSchema{
+												"foo": {
+													IsRequired: true,
+													Constraint: schema.LiteralType{Type: cty.String},
+												},
+											},
+										},
+									},
+									"empty": {},
+								},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" {
+  nested {
+  }
+  empty {
+  }
+}`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Required attribute \"ami\" not specified",
+						Detail:   "An attribute named \"ami\" is required here",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+						},
+					},
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Required attribute \"foo\" not specified",
+						Detail:   "An attribute named \"foo\" is required here",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 28},
+							End:      hcl.Pos{Line: 2, Column: 9, Byte: 34},
+						},
+					},
+				},
+			},
+		},
+		{
+			"unknown attribute in resolved dependent body",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type", IsDepKey: true},
+						},
+						Body: &schema.BodySchema{
+							Blocks: map[string]*schema.BlockSchema{
+								"tags": {
+									Body: &schema.BodySchema{
+										AnyAttribute: &schema.AttributeSchema{
+											Constraint: schema.LiteralType{Type: cty.String},
+										},
+									},
+								},
+							},
+						},
+						DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+							schema.NewSchemaKey(schema.DependencyKeys{
+								Labels: []schema.LabelDependent{
+									{Index: 0, Value: "aws_instance"},
+								},
+							}): {
+								Attributes: map[string]*schema.AttributeSchema{
+									"ami": {
+										Constraint: schema.LiteralType{Type: cty.String},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" {
+  ami = "ami-1234"
+  foo = "bar"
+  tags {
+    anything = "ok"
+  }
+}`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Unexpected attribute",
+						Detail:   "An attribute named \"foo\" is not expected in a \"resource\" block",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 47},
+							End:      hcl.Pos{Line: 3, Column: 6, Byte: 50},
+						},
+					},
+				},
+			},
+		},
+		{
+			"deprecated attribute with message",
+			&schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"wakka": {
+						Constraint:         schema.LiteralType{Type: cty.Number},
+						IsDeprecated:       true,
+						DeprecationMessage: "Use `wakka_wakka` instead",
+					},
+				},
+			},
+			`wakka = 2
+`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagWarning,
+						Summary:  "\"wakka\" is deprecated",
+						Detail:   "Use `wakka_wakka` instead",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 6, Byte: 5},
+						},
+					},
+				},
+			},
+		},
+		{
+			"deprecated block with message",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"foo": {
+						IsDeprecated:       true,
+						DeprecationMessage: "Use `wakka` instead",
+						Labels: []*schema.LabelSchema{
+							{Name: "name"},
+						},
+					},
+				},
+			},
+			`foo "bar" {
+}`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagWarning,
+						Summary:  "\"foo\" is deprecated",
+						Detail:   "Use `wakka` instead",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+						},
+					},
+				},
+			},
+		},
 		{
 			"extra block labels",
 			&schema.BodySchema{
@@ -362,8 +546,46 @@ wakka = 2
 						Detail:   "Only 1 block(s) are expected for \"bar\"",
 						Subject: &hcl.Range{
 							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 1, Column: 5, Byte: 4},
-							End:      hcl.Pos{Line: 6, Column: 5, Byte: 56},
+							Start:    hcl.Pos{Line: 3, Column: 5, Byte: 21},
+							End:      hcl.Pos{Line: 3, Column: 8, Byte: 24},
+						},
+					},
+				},
+			},
+		},
+		{
+			"each extra block reported",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"bar": {
+						MaxItems: 1,
+					},
+				},
+			},
+			`bar {}
+bar {}
+bar {}
+`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Too many blocks specified for \"bar\"",
+						Detail:   "Only 1 block(s) are expected for \"bar\"",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 7},
+							End:      hcl.Pos{Line: 2, Column: 4, Byte: 10},
+						},
+					},
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Too many blocks specified for \"bar\"",
+						Detail:   "Only 1 block(s) are expected for \"bar\"",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 14},
+							End:      hcl.Pos{Line: 3, Column: 4, Byte: 17},
 						},
 					},
 				},
@@ -563,8 +785,8 @@ wakka = 2
 						Detail:   "Only 1 block(s) are expected for \"two\"",
 						Subject: &hcl.Range{
 							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 1, Column: 5, Byte: 4},
-							End:      hcl.Pos{Line: 6, Column: 5, Byte: 56},
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 32},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 35},
 						},
 					},
 				},
@@ -684,7 +906,7 @@ wakka = 2
 						Subject: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 2, Column: 5, Byte: 10},
-							End:      hcl.Pos{Line: 2, Column: 13, Byte: 18},
+							End:      hcl.Pos{Line: 2, Column: 9, Byte: 14},
 						},
 					},
 				},
@@ -885,11 +1107,11 @@ wakka = 2
 					&hcl.Diagnostic{
 						Severity: hcl.DiagError,
 						Summary:  "Unexpected attribute",
-						Detail:   `An attribute named "toot" is not expected here`,
+						Detail:   `An attribute named "toot" is not expected in a "resource" block`,
 						Subject: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 133},
-							End:      hcl.Pos{Line: 8, Column: 18, Byte: 146},
+							End:      hcl.Pos{Line: 8, Column: 9, Byte: 137},
 						},
 					},
 				},
@@ -936,7 +1158,7 @@ wakka = 2
 						Subject: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 65},
-							End:      hcl.Pos{Line: 4, Column: 20, Byte: 80},
+							End:      hcl.Pos{Line: 4, Column: 15, Byte: 75},
 						},
 					},
 				},
@@ -970,6 +1192,562 @@ wakka = 2
 	}
 }
 
+func TestValidate_allowedValuesFunc(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				AllowedValuesFunc: func(ctx context.Context) []cty.Value {
+					return []cty.Value{
+						cty.StringVal("first"),
+						cty.StringVal("second"),
+					}
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"allowed value",
+			`attr = "second"`,
+			nil,
+		},
+		{
+			"unknown value",
+			`attr = var.foo`,
+			nil,
+		},
+		{
+			"disallowed value",
+			`attr = "third"`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid value",
+					Detail:   `The value of "attr" must be one of: "first", "second"`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: testValidators,
+			})
+
+			diags, err := d.ValidateFile(context.Background(), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_collectionShape(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"list_attr": {
+				Constraint: schema.List{
+					Elem: schema.LiteralType{Type: cty.String},
+				},
+				IsOptional: true,
+			},
+			"map_attr": {
+				Constraint: schema.Map{
+					Elem: schema.LiteralType{Type: cty.String},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"matching shapes",
+			`list_attr = ["foo"]
+map_attr = { foo = "bar" }
+`,
+			nil,
+		},
+		{
+			"reference",
+			`list_attr = var.foo
+map_attr = var.bar
+`,
+			nil,
+		},
+		{
+			"list with object",
+			`list_attr = { foo = "bar" }`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid collection shape",
+					Detail:   `Expected a list for "list_attr", got an object`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
+						End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
+					},
+				},
+			},
+		},
+		{
+			"map with tuple",
+			`map_attr = ["foo"]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid collection shape",
+					Detail:   `Expected a map for "map_attr", got a tuple`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: testValidators,
+			})
+
+			diags, err := d.ValidateFile(context.Background(), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_typeMismatch(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"count": {
+				Constraint: schema.LiteralType{Type: cty.Number},
+				IsOptional: true,
+			},
+			"enabled": {
+				Constraint: schema.OneOf{
+					schema.LiteralType{Type: cty.Bool},
+					schema.LiteralValue{Value: cty.StringVal("auto")},
+				},
+				IsOptional: true,
+			},
+			"any": {
+				Constraint: schema.AnyExpression{OfType: cty.Number},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"matching types",
+			`count = 3
+enabled = true
+`,
+			nil,
+		},
+		{
+			"convertible value",
+			`count = "3"
+`,
+			nil,
+		},
+		{
+			"reference",
+			`count = var.foo
+enabled = var.bar
+`,
+			nil,
+		},
+		{
+			"any expression",
+			`any = "three"
+`,
+			nil,
+		},
+		{
+			"null",
+			`count = null
+`,
+			nil,
+		},
+		{
+			"number with string",
+			`count = "three"`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid value type",
+					Detail:   `Expected number for "count", got string`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+				},
+			},
+		},
+		{
+			"one of with matching alternative",
+			`enabled = "auto"`,
+			nil,
+		},
+		{
+			"one of without matching alternative",
+			`enabled = [true]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid value type",
+					Detail:   `Expected bool or string for "enabled", got tuple`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: testValidators,
+			})
+
+			diags, err := d.ValidateFile(context.Background(), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_stringLength(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				MinLength:  3,
+				MaxLength:  8,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"within limits",
+			`name = "foobar"
+`,
+			nil,
+		},
+		{
+			"multi-byte characters within limits",
+			`name = "ééé"
+`,
+			nil,
+		},
+		{
+			"reference",
+			`name = var.foo
+`,
+			nil,
+		},
+		{
+			"too short",
+			`name = "fo"
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Value too short",
+					Detail:   `Value of "name" must be at least 3 characters long, got 2`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+					},
+				},
+			},
+		},
+		{
+			"too long",
+			`name = "foobarbaz"
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Value too long",
+					Detail:   `Value of "name" must be at most 8 characters long, got 9`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: testValidators,
+			})
+
+			diags, err := d.ValidateFile(context.Background(), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_numberOutOfRange(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"port": {
+				Constraint:  schema.LiteralType{Type: cty.Number},
+				IsOptional:  true,
+				NumberRange: &schema.NumberRange{Min: 1, Max: 65535},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"in range",
+			`port = 8080
+`,
+			nil,
+		},
+		{
+			"upper bound",
+			`port = 65535
+`,
+			nil,
+		},
+		{
+			"reference",
+			`port = var.port
+`,
+			nil,
+		},
+		{
+			"below range",
+			`port = 0
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Value out of range",
+					Detail:   `Value of "port" must be within 1-65535, got 0`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+					},
+				},
+			},
+		},
+		{
+			"above range",
+			`port = 70000.5
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Value out of range",
+					Detail:   `Value of "port" must be within 1-65535, got 70000.5`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: testValidators,
+			})
+
+			diags, err := d.ValidateFile(context.Background(), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_nullRequiredAttribute(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"optional_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				IsNullable: true,
+			},
+			"nullable_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+				IsNullable: true,
+			},
+			"required_attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsRequired: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"null for nullable optional",
+			`optional_attr = null
+nullable_attr = "foo"
+required_attr = "foo"
+`,
+			nil,
+		},
+		{
+			"null for nullable required",
+			`nullable_attr = null
+required_attr = "foo"
+`,
+			nil,
+		},
+		{
+			"null for non-nullable required",
+			`nullable_attr = "foo"
+required_attr = null
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  `Required attribute "required_attr" set to null`,
+					Detail:   `The attribute "required_attr" is required and cannot be null`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 17, Byte: 38},
+						End:      hcl.Pos{Line: 2, Column: 21, Byte: 42},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: testValidators,
+			})
+
+			diags, err := d.ValidateFile(context.Background(), "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
 func TestValidate_schema_SingleFile(t *testing.T) {
 	testCases := []struct {
 		testName            string
@@ -1013,7 +1791,7 @@ func TestValidate_schema_SingleFile(t *testing.T) {
 					Subject: &hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 2, Column: 2, Byte: 10},
-						End:      hcl.Pos{Line: 2, Column: 9, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 5, Byte: 13},
 					},
 				},
 			},
@@ -1054,12 +1832,18 @@ func sortDiagnostics(diags hcl.Diagnostics) {
 }
 
 var testValidators = []validator.Validator{
+	validator.AllowedValues{},
 	validator.BlockLabelsLength{},
+	validator.CollectionShape{},
 	validator.DeprecatedAttribute{},
 	validator.DeprecatedBlock{},
 	validator.MaxBlocks{},
 	validator.MinBlocks{},
 	validator.MissingRequiredAttribute{},
+	validator.NullRequiredAttribute{},
+	validator.NumberOutOfRange{},
+	validator.StringLength{},
+	validator.TypeMismatch{},
 	validator.UnexpectedAttribute{},
 	validator.UnexpectedBlock{},
 }
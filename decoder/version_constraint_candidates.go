@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type versionConstraintOperator struct {
+	operator    string
+	description string
+}
+
+var versionConstraintOperators = []versionConstraintOperator{
+	{">=", "Allows the given version or any newer one"},
+	{"~>", "Allows only the right-most version component to increment"},
+	{"=", "Allows only the exact given version"},
+	{"!=", "Excludes the exact given version"},
+	{">", "Allows only versions newer than the given one"},
+	{"<", "Allows only versions older than the given one"},
+	{"<=", "Allows the given version or any older one"},
+}
+
+// versionConstraintCandidates returns snippets for version constraint
+// operators, such as ">= 1.0.0", to be offered for attributes
+// flagged as version constraints. Operators are offered for empty
+// values, or strings only containing the typed prefix of an operator.
+func versionConstraintCandidates(expr hclsyntax.Expression, pos hcl.Pos) []lang.Candidate {
+	prefix := ""
+	editRng := hcl.Range{
+		Filename: expr.Range().Filename,
+		Start:    pos,
+		End:      pos,
+	}
+	if !isEmptyExpression(expr) {
+		var ok bool
+		prefix, ok = quotedLiteralPrefix(expr, pos)
+		if !ok || !isWholeStringPrefix(expr, prefix) {
+			return []lang.Candidate{}
+		}
+		editRng = literalEditRange(expr, pos)
+	}
+
+	candidates := make([]lang.Candidate, 0, len(versionConstraintOperators))
+	for _, op := range versionConstraintOperators {
+		if !strings.HasPrefix(op.operator, prefix) {
+			continue
+		}
+		candidates = append(candidates, lang.Candidate{
+			Label:       fmt.Sprintf(`"%s version"`, op.operator),
+			Detail:      "version constraint",
+			Description: lang.PlainText(op.description),
+			Kind:        lang.StringCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: fmt.Sprintf(`"%s 1.0.0"`, op.operator),
+				Snippet: fmt.Sprintf(`"%s ${1:1.0.0}"`, op.operator),
+				Range:   editRng,
+			},
+		})
+	}
+
+	return candidates
+}
+
+// isWholeStringPrefix reports whether the prefix typed
+// inside the quoted string is all the string contains,
+// i.e. there is no text after the cursor
+func isWholeStringPrefix(expr hclsyntax.Expression, prefix string) bool {
+	tplExpr, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok {
+		return false
+	}
+	if len(tplExpr.Parts) == 0 {
+		return prefix == ""
+	}
+	part, ok := tplExpr.Parts[0].(*hclsyntax.LiteralValueExpr)
+	if !ok {
+		return false
+	}
+	return part.Val.AsString() == prefix
+}
@@ -47,6 +47,16 @@ type Candidate struct {
 	// to reopen candidate suggestion popup after insertion
 	TriggerSuggest bool
 
+	// TriggerSuggestChars optionally lists characters which,
+	// when typed after insertion, should reopen the candidate
+	// suggestion popup, such as " for block labels
+	// or . for nested attributes of references
+	TriggerSuggestChars []string
+
+	// Command represents an optional command to be executed
+	// by the client after insertion, e.g. to trigger signature help
+	Command *Command
+
 	// ResolveHook allows resolution of additional information
 	// for a completion candidate via ResolveCandidate.
 	ResolveHook *ResolveHook
@@ -85,7 +95,16 @@ func (ca Candidates) Len() int {
 
 func (ca Candidates) Less(i, j int) bool {
 	// TODO: sort by more metadata, such as IsRequired or IsDeprecated
-	return ca.List[i].Label < ca.List[j].Label
+	return ca.List[i].sortKey() < ca.List[j].sortKey()
+}
+
+// sortKey mimics how clients order candidates, i.e. by SortText
+// if it is set and by Label otherwise
+func (c Candidate) sortKey() string {
+	if c.SortText != "" {
+		return c.SortText
+	}
+	return c.Label
 }
 
 func (ca Candidates) Swap(i, j int) {
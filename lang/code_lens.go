@@ -11,6 +11,10 @@ import (
 
 type CodeLensFunc func(ctx context.Context, path Path, file string) ([]CodeLens, error)
 
+// BlockCodeLensFunc produces code lenses for the given block,
+// such as number of references to an addressable block
+type BlockCodeLensFunc func(ctx context.Context, path Path, block *hcl.Block) ([]CodeLens, error)
+
 type CodeLens struct {
 	Range   hcl.Range
 	Command Command
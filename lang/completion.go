@@ -3,6 +3,10 @@
 
 package lang
 
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
 type CompletionHook struct {
 	Name string
 }
@@ -10,6 +14,15 @@ type CompletionHook struct {
 type ResolveHook struct {
 	Name string `json:"resolve_hook,omitempty"`
 	Path string `json:"path,omitempty"`
+
+	// LanguageID, Filename, Pos and Field locate the candidate
+	// for resolve hooks built into the decoder, i.e. the position
+	// the completion was requested at and the attribute name
+	// or block type the candidate represents
+	LanguageID string   `json:"language_id,omitempty"`
+	Filename   string   `json:"filename,omitempty"`
+	Pos        *hcl.Pos `json:"pos,omitempty"`
+	Field      string   `json:"field,omitempty"`
 }
 
 type CompletionHooks []CompletionHook
@@ -67,5 +67,11 @@ func (stm SemanticTokenModifiers) Copy() SemanticTokenModifiers {
 }
 
 const (
-	TokenModifierDependent = SemanticTokenModifier("hcl-dependent")
+	TokenModifierDependent  = SemanticTokenModifier("hcl-dependent")
+	TokenModifierDeprecated = SemanticTokenModifier("deprecated")
 )
+
+var SupportedSemanticTokenModifiers = SemanticTokenModifiers{
+	TokenModifierDependent,
+	TokenModifierDeprecated,
+}
@@ -142,7 +142,21 @@ func (r Target) TargetRange() (hcl.Range, bool) {
 }
 
 func (target Target) MatchesConstraint(ref schema.Reference) bool {
-	return target.MatchesScopeId(ref.OfScopeId) && target.IsConvertibleToType(ref.OfType)
+	return target.MatchesAnyScopeId(ref.ScopeIds()) && target.IsConvertibleToType(ref.OfType)
+}
+
+// MatchesAnyScopeId reports whether the target is in any of the given
+// scopes, where no scopes mean any scope is acceptable
+func (ref Target) MatchesAnyScopeId(scopeIds []lang.ScopeId) bool {
+	if len(scopeIds) == 0 {
+		return true
+	}
+	for _, scopeId := range scopeIds {
+		if ref.MatchesScopeId(scopeId) {
+			return true
+		}
+	}
+	return false
 }
 
 func (ref Target) MatchesScopeId(scopeId lang.ScopeId) bool {
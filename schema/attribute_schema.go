@@ -4,21 +4,48 @@
 package schema
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // AttributeSchema describes schema for an attribute
 type AttributeSchema struct {
-	Description  lang.MarkupContent
+	Description lang.MarkupContent
+
+	// DescriptionFunc represents an optional function which provides
+	// the description lazily, e.g. to avoid keeping descriptions
+	// of large schemas in memory. It takes precedence over Description
+	// and is only called when the description is displayed.
+	DescriptionFunc DescriptionFunc
+
 	IsRequired   bool
 	IsOptional   bool
 	IsDeprecated bool
 	IsComputed   bool
 	IsSensitive  bool
 
+	// IsNullable defines whether null is a valid value for
+	// a required attribute, i.e. whether assigning null
+	// is not equivalent to leaving the attribute unset.
+	IsNullable bool
+
+	// DeprecationMessage optionally explains why the attribute
+	// is deprecated and what to use instead.
+	DeprecationMessage string
+
+	// MinLength and MaxLength optionally limit the number of characters
+	// of a string value. Zero means no limit.
+	MinLength uint64
+	MaxLength uint64
+
+	// NumberRange optionally limits numeric values
+	// to an inclusive range.
+	NumberRange *NumberRange
+
 	// If true, this attribute is write only and its value will not be
 	// persisted in artifacts such as plan files or state.
 	IsWriteOnly bool
@@ -36,6 +63,19 @@ type AttributeSchema struct {
 	// attribute-dependent body).
 	DefaultValue Default
 
+	// DefaultSnippet optionally overrides the snippet inserted
+	// as the attribute value when completing the attribute name,
+	// e.g. ${1:us-east-1} instead of the type-derived ${1:value}.
+	// It is used verbatim and should start placeholders at 1.
+	DefaultSnippet string
+
+	// Priority optionally moves the attribute ahead of others
+	// in completion, such that important attributes of large
+	// schemas are listed first. Attributes with lower non-zero
+	// priority are listed first, then ones without priority,
+	// with ties ordered by name.
+	Priority uint
+
 	// IsDepKey describes whether to use this attribute (and its value)
 	// as key when looking up dependent schema
 	IsDepKey bool
@@ -58,8 +98,41 @@ type AttributeSchema struct {
 	// These are typically candidates which cannot be provided
 	// via schema and come from external APIs or other sources.
 	CompletionHooks lang.CompletionHooks
+
+	// IsVersionConstraint indicates whether the attribute value
+	// is a version constraint string (e.g. ">= 1.0.0"), in which case
+	// version constraint operator snippets are offered in completion.
+	IsVersionConstraint bool
+
+	// AllowedValuesFunc represents an optional function which computes
+	// allowed values of the attribute dynamically, e.g. based on other
+	// configuration, rather than being declared statically in Constraint.
+	// The values are offered in completion and validated against.
+	AllowedValuesFunc AllowedValuesFunc
+
+	// DocsLink represents a link to docs for the attribute
+	// that will be exposed as part of LinksInFile()
+	DocsLink *DocsLink
+
+	// NamePlaceholder represents the attribute name suggested
+	// in completion when the schema is used as AnyAttribute,
+	// e.g. provider_name. Defaults to "name" if empty.
+	NamePlaceholder string
+
+	// NameScopeId makes completion suggest names of reference
+	// targets of the given scope when the schema is used
+	// as AnyAttribute, e.g. names of providers referenced
+	// elsewhere in the configuration. NamePlaceholder
+	// is used if no such targets are found.
+	NameScopeId lang.ScopeId
 }
 
+// DescriptionFunc returns a description of a schema item
+type DescriptionFunc func() lang.MarkupContent
+
+// AllowedValuesFunc returns a list of values allowed for an attribute
+type AllowedValuesFunc func(ctx context.Context) []cty.Value
+
 type AttributeAddrSchema struct {
 	// Steps describes address steps used to describe the attribute as whole.
 	// The last step would typically be AttrNameStep{}.
@@ -106,6 +179,14 @@ func (as *AttributeSchema) Validate() error {
 		return errors.New("one of IsRequired, IsOptional, or IsComputed must be set")
 	}
 
+	if as.MaxLength != 0 && as.MinLength > as.MaxLength {
+		return errors.New("MinLength cannot be greater than MaxLength")
+	}
+
+	if as.NumberRange != nil && as.NumberRange.Min > as.NumberRange.Max {
+		return errors.New("NumberRange: Min cannot be greater than Max")
+	}
+
 	if as.Address != nil {
 		if !as.Address.AsExprType && !as.Address.AsReference {
 			return fmt.Errorf("Address: at least one of AsExprType or AsReference must be set")
@@ -141,16 +222,29 @@ func (as *AttributeSchema) Copy() *AttributeSchema {
 		IsRequired:             as.IsRequired,
 		IsOptional:             as.IsOptional,
 		IsDeprecated:           as.IsDeprecated,
+		DeprecationMessage:     as.DeprecationMessage,
 		IsComputed:             as.IsComputed,
 		IsSensitive:            as.IsSensitive,
+		IsNullable:             as.IsNullable,
+		MinLength:              as.MinLength,
+		MaxLength:              as.MaxLength,
+		NumberRange:            as.NumberRange.Copy(),
 		IsWriteOnly:            as.IsWriteOnly,
 		IsDepKey:               as.IsDepKey,
 		DefaultValue:           as.DefaultValue,
+		DefaultSnippet:         as.DefaultSnippet,
+		Priority:               as.Priority,
 		Description:            as.Description,
+		DescriptionFunc:        as.DescriptionFunc,
 		Address:                as.Address.Copy(),
 		OriginForTarget:        as.OriginForTarget.Copy(),
 		SemanticTokenModifiers: as.SemanticTokenModifiers.Copy(),
 		CompletionHooks:        as.CompletionHooks.Copy(),
+		IsVersionConstraint:    as.IsVersionConstraint,
+		AllowedValuesFunc:      as.AllowedValuesFunc,
+		DocsLink:               as.DocsLink.Copy(),
+		NamePlaceholder:        as.NamePlaceholder,
+		NameScopeId:            as.NameScopeId,
 		// We do not copy Constraint as it should be immutable
 		Constraint: as.Constraint,
 	}
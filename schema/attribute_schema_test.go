@@ -49,6 +49,23 @@ func TestAttributeSchema_Validate(t *testing.T) {
 			},
 			errors.New("cannot be both IsRequired and IsComputed"),
 		},
+		{
+			&AttributeSchema{
+				Constraint: LiteralType{Type: cty.String},
+				IsRequired: true,
+				MinLength:  10,
+				MaxLength:  5,
+			},
+			errors.New("MinLength cannot be greater than MaxLength"),
+		},
+		{
+			&AttributeSchema{
+				Constraint:  LiteralType{Type: cty.Number},
+				IsRequired:  true,
+				NumberRange: &NumberRange{Min: 10, Max: 1},
+			},
+			errors.New("NumberRange: Min cannot be greater than Max"),
+		},
 		{
 			&AttributeSchema{
 				Constraint: LiteralType{Type: cty.String},
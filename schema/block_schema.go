@@ -30,12 +30,27 @@ type BlockSchema struct {
 	// depending on SchemaKey (labels or attributes)
 	DependentBody map[SchemaKey]*BodySchema
 
-	Description  lang.MarkupContent
+	Description lang.MarkupContent
+
+	// DescriptionFunc represents an optional function which provides
+	// the description lazily. It takes precedence over Description
+	// and is only called when the description is displayed.
+	DescriptionFunc DescriptionFunc
+
 	IsDeprecated bool
-	MinItems     uint64
-	MaxItems     uint64
+
+	// DeprecationMessage optionally explains why the block
+	// is deprecated and what to use instead.
+	DeprecationMessage string
+
+	MinItems uint64
+	MaxItems uint64
 
 	Address *BlockAddrSchema
+
+	// DocsLink represents a link to docs for the block type
+	// that will be exposed as part of LinksInFile()
+	DocsLink *DocsLink
 }
 
 type BlockAddrSchema struct {
@@ -107,6 +122,12 @@ type BlockAddrSchema struct {
 	// BodySchema.Extensions.SelfRef, where only self.* origins
 	// within a body w/ SelfRef:true will be collected.
 	DependentBodySelfRef bool
+
+	// ExcludeSelfReferences leaves out reference origins within
+	// the block body (including any nested blocks) which target
+	// the block itself or any of its attributes, unless the body
+	// allows self references via BodySchema.Extensions.SelfRefs.
+	ExcludeSelfReferences bool
 }
 
 type BlockAsTypeOf struct {
@@ -152,6 +173,7 @@ func (bas *BlockAddrSchema) Copy() *BlockAddrSchema {
 		InferDependentBody:       bas.InferDependentBody,
 		DependentBodySelfRef:     bas.DependentBodySelfRef,
 		SupportUnknownNestedRefs: bas.SupportUnknownNestedRefs,
+		ExcludeSelfReferences:    bas.ExcludeSelfReferences,
 		Steps:                    bas.Steps.Copy(),
 	}
 
@@ -205,11 +227,14 @@ func (bs *BlockSchema) Copy() *BlockSchema {
 		Type:                   bs.Type,
 		SemanticTokenModifiers: bs.SemanticTokenModifiers.Copy(),
 		IsDeprecated:           bs.IsDeprecated,
+		DeprecationMessage:     bs.DeprecationMessage,
 		MinItems:               bs.MinItems,
 		MaxItems:               bs.MaxItems,
 		Description:            bs.Description,
+		DescriptionFunc:        bs.DescriptionFunc,
 		Body:                   bs.Body.Copy(),
 		Address:                bs.Address.Copy(),
+		DocsLink:               bs.DocsLink.Copy(),
 	}
 
 	if bs.Labels != nil {
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"fmt"
+)
+
+// SnippetStyle describes options for generating snippets
+type SnippetStyle struct {
+	// PrefillRequiredFields produces a snippet which is compatible
+	// with required fields prefilled later via label completion,
+	// i.e. the snippet of a block with dependent labels ends
+	// at the dependent label rather than in the body.
+	PrefillRequiredFields bool
+}
+
+// Snippet returns the snippet which inserts a block of the given type,
+// including its labels and an empty body, as offered in completion.
+func (bs *BlockSchema) Snippet(blockType string, style SnippetStyle) string {
+	if style.PrefillRequiredFields {
+		labels := ""
+
+		depKey := false
+		for _, l := range bs.Labels {
+			if l.IsDepKey {
+				depKey = true
+			}
+		}
+
+		if depKey {
+			for _, l := range bs.Labels {
+				if l.IsDepKey {
+					labels += ` "${0}"`
+				} else {
+					labels += fmt.Sprintf(` "%s"`, l.Name)
+				}
+			}
+			return fmt.Sprintf("%s%s {\n}", blockType, labels)
+		}
+
+		placeholder := 1
+		for _, l := range bs.Labels {
+			labels += fmt.Sprintf(` "${%d:%s}"`, placeholder, l.Name)
+			placeholder++
+		}
+
+		if attrs, ok := requiredAttributesSnippet(bs.Body, placeholder); ok {
+			return fmt.Sprintf("%s%s {\n%s}", blockType, labels, attrs)
+		}
+
+		return fmt.Sprintf("%s%s {\n  ${%d}\n}", blockType, labels, placeholder)
+	}
+
+	labels := ""
+	placeholder := 1
+
+	for _, l := range bs.Labels {
+		if l.IsDepKey {
+			labels += fmt.Sprintf(` "${%d}"`, placeholder)
+		} else {
+			labels += fmt.Sprintf(` "${%d:%s}"`, placeholder, l.Name)
+		}
+		placeholder++
+	}
+
+	return fmt.Sprintf("%s%s {\n  ${%d}\n}", blockType, labels, placeholder)
+}
+
+// requiredAttributesSnippet returns a line per each required attribute
+// of the (statically known) body, with tab stops numbered sequentially
+// from the given placeholder
+func requiredAttributesSnippet(body *BodySchema, placeholder int) (string, bool) {
+	if body == nil {
+		return "", false
+	}
+
+	ctx := WithPrefillRequiredFields(context.Background(), true)
+	snippet := ""
+	for _, name := range body.AttributeNames() {
+		attr := body.Attributes[name]
+		if !attr.IsRequired || attr.Constraint == nil {
+			continue
+		}
+
+		cData := attr.Constraint.EmptyCompletionData(ctx, placeholder, 1)
+		if cData.Snippet == "" {
+			return "", false
+		}
+		snippet += fmt.Sprintf("  %s = %s\n", name, cData.Snippet)
+
+		if cData.NextPlaceholder > placeholder {
+			placeholder = cData.NextPlaceholder
+		} else {
+			placeholder++
+		}
+	}
+
+	return snippet, snippet != ""
+}
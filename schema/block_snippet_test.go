@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestBlockSchema_Snippet_prefillRequiredFields(t *testing.T) {
+	testCases := []struct {
+		name            string
+		schema          *BlockSchema
+		expectedSnippet string
+	}{
+		{
+			"no required attributes",
+			&BlockSchema{
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"foo": {IsOptional: true, Constraint: LiteralType{Type: cty.String}},
+					},
+				},
+			},
+			"block {\n  ${1}\n}",
+		},
+		{
+			"required attributes",
+			&BlockSchema{
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"bar": {IsRequired: true, Constraint: LiteralType{Type: cty.Number}},
+						"baz": {IsOptional: true, Constraint: LiteralType{Type: cty.String}},
+						"foo": {IsRequired: true, Constraint: LiteralType{Type: cty.String}},
+					},
+				},
+			},
+			"block {\n  bar = ${1:0}\n  foo = \"${2:value}\"\n}",
+		},
+		{
+			"labels and required attributes",
+			&BlockSchema{
+				Labels: []*LabelSchema{
+					{Name: "type"}, {Name: "name"},
+				},
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"foo": {IsRequired: true, Constraint: LiteralType{Type: cty.String}},
+					},
+				},
+			},
+			"block \"${1:type}\" \"${2:name}\" {\n  foo = \"${3:value}\"\n}",
+		},
+		{
+			"multiple tab stops per attribute",
+			&BlockSchema{
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"bar": {
+							IsRequired: true,
+							Constraint: Object{
+								Attributes: ObjectAttributes{
+									"one": {IsRequired: true, Constraint: LiteralType{Type: cty.String}},
+									"two": {IsRequired: true, Constraint: LiteralType{Type: cty.Number}},
+								},
+							},
+						},
+						"foo": {IsRequired: true, Constraint: LiteralType{Type: cty.Bool}},
+					},
+				},
+			},
+			"block {\n  bar = {\n    one = \"${1:value}\"\n    two = ${2:0}\n  }\n  foo = ${3:false}\n}",
+		},
+		{
+			"dependent labels",
+			&BlockSchema{
+				Labels: []*LabelSchema{
+					{Name: "type", IsDepKey: true}, {Name: "name"},
+				},
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"foo": {IsRequired: true, Constraint: LiteralType{Type: cty.String}},
+					},
+				},
+			},
+			"block \"${0}\" \"name\" {\n}",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			snippet := tc.schema.Snippet("block", SnippetStyle{PrefillRequiredFields: true})
+			if diff := cmp.Diff(tc.expectedSnippet, snippet); diff != "" {
+				t.Fatalf("unexpected snippet: %s", diff)
+			}
+		})
+	}
+}
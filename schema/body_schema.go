@@ -26,6 +26,12 @@ type BodySchema struct {
 	Detail       string
 	Description  lang.MarkupContent
 
+	// Namespace represents an optional namespace the body belongs to,
+	// such as the source of a provider in Terraform. It may be used to
+	// qualify label completion candidates when the body is dependent
+	// on a label.
+	Namespace string
+
 	// DocsLink represents a link to docs that will be exposed
 	// as part of LinksInFile()
 	DocsLink *DocsLink
@@ -58,6 +64,11 @@ type BodyExtensions struct {
 	ForEach       bool // for_each attribute + each.* refs
 	DynamicBlocks bool // dynamic "block-name" w/ content & for_each inside
 	SelfRefs      bool // self.* refs
+	DependsOn     bool // depends_on attribute w/ references to other blocks
+
+	// DependsOnScopeIds represents scopes of blocks
+	// which can be referenced from the depends_on attribute
+	DependsOnScopeIds []lang.ScopeId
 }
 
 func (be *BodyExtensions) Copy() *BodyExtensions {
@@ -65,11 +76,19 @@ func (be *BodyExtensions) Copy() *BodyExtensions {
 		return nil
 	}
 
+	var scopeIds []lang.ScopeId
+	if be.DependsOnScopeIds != nil {
+		scopeIds = make([]lang.ScopeId, len(be.DependsOnScopeIds))
+		copy(scopeIds, be.DependsOnScopeIds)
+	}
+
 	return &BodyExtensions{
-		Count:         be.Count,
-		ForEach:       be.ForEach,
-		DynamicBlocks: be.DynamicBlocks,
-		SelfRefs:      be.SelfRefs,
+		Count:             be.Count,
+		ForEach:           be.ForEach,
+		DynamicBlocks:     be.DynamicBlocks,
+		SelfRefs:          be.SelfRefs,
+		DependsOn:         be.DependsOn,
+		DependsOnScopeIds: scopeIds,
 	}
 }
 
@@ -166,6 +185,10 @@ func (bs *BodySchema) Validate() error {
 	}
 
 	var result *multierror.Error
+	if bs.Extensions != nil && bs.Extensions.DependsOn && len(bs.Extensions.DependsOnScopeIds) == 0 {
+		result = multierror.Append(result, fmt.Errorf("DependsOn requires DependsOnScopeIds"))
+	}
+
 	for name, attr := range bs.Attributes {
 		err := attr.Validate()
 		if err != nil {
@@ -198,6 +221,7 @@ func (bs *BodySchema) Copy() *BodySchema {
 		IsDeprecated: bs.IsDeprecated,
 		Detail:       bs.Detail,
 		Description:  bs.Description,
+		Namespace:    bs.Namespace,
 		AnyAttribute: bs.AnyAttribute.Copy(),
 		HoverURL:     bs.HoverURL,
 		DocsLink:     bs.DocsLink.Copy(),
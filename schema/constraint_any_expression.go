@@ -21,6 +21,11 @@ type AnyExpression struct {
 	// SkipLiteralComplexTypes avoids descending into complex literal types, such as {} and [].
 	// It might be required when AnyExpression is used in OneOf to avoid duplicates.
 	SkipLiteralComplexTypes bool
+
+	// PreferReferences hints that the expression is usually a reference,
+	// such that reference candidates are ranked ahead of any others
+	// in completion, while still allowing any expression.
+	PreferReferences bool
 }
 
 func (AnyExpression) isConstraintImpl() constraintSigil {
@@ -35,6 +40,7 @@ func (ae AnyExpression) Copy() Constraint {
 	return AnyExpression{
 		OfType:                  ae.OfType,
 		SkipLiteralComplexTypes: ae.SkipLiteralComplexTypes,
+		PreferReferences:        ae.PreferReferences,
 	}
 }
 
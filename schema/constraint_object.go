@@ -108,17 +108,17 @@ func (o Object) attributesCompletionData(ctx context.Context, placeholder, nesti
 
 	for _, name := range attrNames {
 		attr := o.Attributes[name]
+		if !attr.IsRequired {
+			// optional attributes are left out of the prefilled object
+			continue
+		}
+		anyRequiredFields = true
+
 		attrData := attr.Constraint.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel+1)
 		if attrData.NewText == "" || attrData.Snippet == "" {
 			return CompletionData{}, false
 		}
 
-		if attr.IsRequired {
-			anyRequiredFields = true
-		} else {
-			continue
-		}
-
 		newText += fmt.Sprintf("%s%s = %s\n", attrNesting, name, attrData.NewText)
 		snippet += fmt.Sprintf("%s%s = %s\n", attrNesting, name, attrData.Snippet)
 		nextPlaceholder = attrData.NextPlaceholder
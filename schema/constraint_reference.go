@@ -18,6 +18,10 @@ type Reference struct {
 	// OfScopeId defines scope of a type-less reference
 	OfScopeId lang.ScopeId
 
+	// OfScopeIds defines multiple acceptable scopes
+	// of a type-less reference, in addition to OfScopeId
+	OfScopeIds []lang.ScopeId
+
 	// OfType defines the type of a type-aware reference
 	OfType cty.Type
 
@@ -61,12 +65,34 @@ func (ref Reference) FriendlyName() string {
 }
 
 func (ref Reference) Copy() Constraint {
+	var scopeIds []lang.ScopeId
+	if ref.OfScopeIds != nil {
+		scopeIds = make([]lang.ScopeId, len(ref.OfScopeIds))
+		copy(scopeIds, ref.OfScopeIds)
+	}
+
 	return Reference{
-		OfScopeId: ref.OfScopeId,
-		OfType:    ref.OfType,
-		Name:      ref.Name,
-		Address:   ref.Address.Copy(),
+		OfScopeId:  ref.OfScopeId,
+		OfScopeIds: scopeIds,
+		OfType:     ref.OfType,
+		Name:       ref.Name,
+		Address:    ref.Address.Copy(),
+	}
+}
+
+// ScopeIds returns all acceptable scopes of the reference,
+// i.e. OfScopeId (if any) followed by OfScopeIds
+func (ref Reference) ScopeIds() []lang.ScopeId {
+	scopeIds := make([]lang.ScopeId, 0, len(ref.OfScopeIds)+1)
+	if ref.OfScopeId != "" {
+		scopeIds = append(scopeIds, ref.OfScopeId)
+	}
+	for _, scopeId := range ref.OfScopeIds {
+		if scopeId != "" {
+			scopeIds = append(scopeIds, scopeId)
+		}
 	}
+	return scopeIds
 }
 
 func (ref Reference) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nestingLevel int) CompletionData {
@@ -78,13 +104,13 @@ func (ref Reference) EmptyCompletionData(ctx context.Context, nextPlaceholder in
 }
 
 func (ref Reference) Validate() error {
-	if ref.Address != nil && (ref.OfType != cty.NilType || ref.OfScopeId != "") {
+	if ref.Address != nil && (ref.OfType != cty.NilType || len(ref.ScopeIds()) > 0) {
 		return errors.New("cannot have both Address and OfType/OfScopeId set")
 	}
 	if ref.Address != nil && ref.Address.ScopeId == "" {
 		return errors.New("Address requires non-empty ScopeId")
 	}
-	if ref.OfType == cty.NilType && ref.OfScopeId == "" && ref.Address == nil {
+	if ref.OfType == cty.NilType && len(ref.ScopeIds()) == 0 && ref.Address == nil {
 		return errors.New("one of OfType, OfScopeId and Address is required")
 	}
 	return nil
@@ -857,6 +857,40 @@ STRING
 				NextPlaceholder: 3,
 			},
 		},
+		{
+			Object{
+				Attributes: map[string]*AttributeSchema{
+					"foo": {
+						Constraint: LiteralType{
+							Type: cty.Bool,
+						},
+						IsRequired: true,
+					},
+					"bar": {
+						Constraint: LiteralType{
+							Type: cty.String,
+						},
+						IsOptional: true,
+					},
+					"baz": {
+						Constraint: Reference{
+							OfScopeId: lang.ScopeId("foo"),
+						},
+						IsOptional: true,
+					},
+				},
+			},
+			true,
+			CompletionData{
+				NewText: `{
+  foo = false
+}`,
+				Snippet: `{
+  foo = ${1:false}
+}`,
+				NextPlaceholder: 2,
+			},
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%2d", i), func(t *testing.T) {
@@ -869,3 +903,53 @@ STRING
 		})
 	}
 }
+
+func TestConstraint_FriendlyName(t *testing.T) {
+	testCases := []struct {
+		cons         Constraint
+		expectedName string
+	}{
+		{LiteralType{Type: cty.String}, "string"},
+		{LiteralType{Type: cty.List(cty.Number)}, "list of number"},
+		{LiteralValue{Value: cty.True}, "bool"},
+		{Keyword{Keyword: "foo"}, "keyword"},
+		{Keyword{Keyword: "foo", Name: "custom"}, "custom"},
+		{Reference{}, "reference"},
+		{Reference{OfType: cty.String}, "string"},
+		{Reference{Name: "block address"}, "block address"},
+		{AnyExpression{OfType: cty.Bool}, "bool"},
+		{TypeDeclaration{}, "type"},
+		{List{}, "list"},
+		{List{Elem: LiteralType{Type: cty.String}}, "list of string"},
+		{Set{}, "set"},
+		{Set{Elem: AnyExpression{OfType: cty.DynamicPseudoType}}, "set of any type"},
+		{Map{}, "map"},
+		{Map{Elem: Keyword{Keyword: "foo"}}, "map of keyword"},
+		{Map{Name: "tags"}, "tags"},
+		{Object{}, "object"},
+		{Object{Name: "settings"}, "settings"},
+		{Tuple{}, "tuple"},
+		{
+			OneOf{
+				LiteralType{Type: cty.Set(cty.DynamicPseudoType)},
+				LiteralType{Type: cty.Map(cty.DynamicPseudoType)},
+			},
+			"set of any single type or map of any single type",
+		},
+		{
+			OneOf{
+				LiteralValue{Value: cty.StringVal("foo")},
+				LiteralValue{Value: cty.StringVal("bar")},
+			},
+			"string",
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d", i), func(t *testing.T) {
+			name := tc.cons.FriendlyName()
+			if name != tc.expectedName {
+				t.Fatalf("unexpected friendly name: %q, expected %q", name, tc.expectedName)
+			}
+		})
+	}
+}
@@ -16,3 +16,52 @@ func WithActiveSelfRefs(ctx context.Context) context.Context {
 func ActiveSelfRefsFromContext(ctx context.Context) bool {
 	return ctx.Value(bodyActiveSelfRefsCtxKey{}) != nil
 }
+
+type bodyExtensionsCtxKey struct{}
+
+// WithBodyExtensions sets the body extensions enabled for the body
+// currently being decoded. Unlike self references, the extensions
+// only apply to the body they were declared for, so any value
+// set for an outer body is replaced (including by nil).
+func WithBodyExtensions(ctx context.Context, ext *BodyExtensions) context.Context {
+	return context.WithValue(ctx, bodyExtensionsCtxKey{}, ext.Copy())
+}
+
+// BodyExtensionsFromContext returns the body extensions
+// set via WithBodyExtensions, or nil if none are enabled.
+func BodyExtensionsFromContext(ctx context.Context) *BodyExtensions {
+	ext, _ := ctx.Value(bodyExtensionsCtxKey{}).(*BodyExtensions)
+	return ext
+}
+
+// WithActiveCount enables the count extension for the body
+// currently being decoded, keeping any other extensions.
+func WithActiveCount(ctx context.Context) context.Context {
+	ext := BodyExtensionsFromContext(ctx).Copy()
+	if ext == nil {
+		ext = &BodyExtensions{}
+	}
+	ext.Count = true
+	return WithBodyExtensions(ctx, ext)
+}
+
+func ActiveCountFromContext(ctx context.Context) bool {
+	ext := BodyExtensionsFromContext(ctx)
+	return ext != nil && ext.Count
+}
+
+// WithActiveForEach enables the for_each extension for the body
+// currently being decoded, keeping any other extensions.
+func WithActiveForEach(ctx context.Context) context.Context {
+	ext := BodyExtensionsFromContext(ctx).Copy()
+	if ext == nil {
+		ext = &BodyExtensions{}
+	}
+	ext.ForEach = true
+	return WithBodyExtensions(ctx, ext)
+}
+
+func ActiveForEachFromContext(ctx context.Context) bool {
+	ext := BodyExtensionsFromContext(ctx)
+	return ext != nil && ext.ForEach
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBodyExtensionsFromContext(t *testing.T) {
+	ctx := context.Background()
+	if ext := BodyExtensionsFromContext(ctx); ext != nil {
+		t.Fatalf("expected no extensions, given %#v", ext)
+	}
+
+	outerCtx := WithBodyExtensions(ctx, &BodyExtensions{Count: true, SelfRefs: true})
+	if !ActiveCountFromContext(outerCtx) {
+		t.Fatal("expected count to be active")
+	}
+	if ActiveForEachFromContext(outerCtx) {
+		t.Fatal("expected for_each to be inactive")
+	}
+
+	forEachCtx := WithActiveForEach(outerCtx)
+	expectedExt := &BodyExtensions{Count: true, ForEach: true, SelfRefs: true}
+	if diff := cmp.Diff(expectedExt, BodyExtensionsFromContext(forEachCtx)); diff != "" {
+		t.Fatalf("unexpected extensions: %s", diff)
+	}
+	if ActiveForEachFromContext(outerCtx) {
+		t.Fatal("expected outer context to remain unchanged")
+	}
+
+	innerCtx := WithBodyExtensions(outerCtx, nil)
+	if ext := BodyExtensionsFromContext(innerCtx); ext != nil {
+		t.Fatalf("expected extensions to be reset for inner body, given %#v", ext)
+	}
+	if ActiveCountFromContext(innerCtx) {
+		t.Fatal("expected count to be inactive in inner body")
+	}
+}
+
+func TestWithActiveCount(t *testing.T) {
+	ctx := WithActiveCount(context.Background())
+	if !ActiveCountFromContext(ctx) {
+		t.Fatal("expected count to be active")
+	}
+	if ActiveForEachFromContext(ctx) {
+		t.Fatal("expected for_each to be inactive")
+	}
+
+	ctx = WithActiveForEach(ctx)
+	if !ActiveCountFromContext(ctx) || !ActiveForEachFromContext(ctx) {
+		t.Fatal("expected both count and for_each to be active")
+	}
+}
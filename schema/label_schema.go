@@ -24,6 +24,12 @@ type LabelSchema struct {
 	// within Blocks's DependentBody can be used for completion
 	// This enables such behaviour.
 	Completable bool
+
+	// CompletionTargets makes the label completable from addresses
+	// of reference targets matching the scope and type (if any),
+	// e.g. addresses of existing resources in a moved block.
+	// It takes precedence over Completable.
+	CompletionTargets *Reference
 }
 
 func (*LabelSchema) isSchemaImpl() schemaImplSigil {
@@ -35,11 +41,18 @@ func (ls *LabelSchema) Copy() *LabelSchema {
 		return nil
 	}
 
-	return &LabelSchema{
+	newLs := &LabelSchema{
 		Name:                   ls.Name,
 		SemanticTokenModifiers: ls.SemanticTokenModifiers.Copy(),
 		Completable:            ls.Completable,
 		Description:            ls.Description,
 		IsDepKey:               ls.IsDepKey,
 	}
+
+	if ls.CompletionTargets != nil {
+		ref := ls.CompletionTargets.Copy().(Reference)
+		newLs.CompletionTargets = &ref
+	}
+
+	return newLs
 }
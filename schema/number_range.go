@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// NumberRange describes an inclusive range of allowed numbers,
+// such as 1-65535 for a port
+type NumberRange struct {
+	Min float64
+	Max float64
+}
+
+func (nr *NumberRange) Copy() *NumberRange {
+	if nr == nil {
+		return nil
+	}
+
+	return &NumberRange{
+		Min: nr.Min,
+		Max: nr.Max,
+	}
+}
+
+// Contains returns true if the given known number is within the range
+func (nr *NumberRange) Contains(val cty.Value) bool {
+	bf := val.AsBigFloat()
+	return bf.Cmp(big.NewFloat(nr.Min)) >= 0 && bf.Cmp(big.NewFloat(nr.Max)) <= 0
+}
+
+func (nr *NumberRange) String() string {
+	return fmt.Sprintf("%s-%s", formatNumber(nr.Min), formatNumber(nr.Max))
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -3,12 +3,18 @@
 
 package schemacontext
 
-import "context"
+import (
+	"context"
+
+	"github.com/hashicorp/hcl/v2"
+)
 
 type unknownSchemaCtxKey struct{}
 type foundBlocksCtxKey struct{}
 type dynamicBlocksCtxKey struct{}
 type blockNestingLevelCtxKey struct{}
+type blockDefRangeCtxKey struct{}
+type blockTypeCtxKey struct{}
 
 // WithUnknownSchema attaches a flag indicating that the schema being passed
 // is not wholly known.
@@ -52,3 +58,29 @@ func BlockNestingLevel(ctx context.Context) (uint64, bool) {
 	lvl, ok := ctx.Value(blockNestingLevelCtxKey{}).(uint64)
 	return lvl, ok
 }
+
+// WithBlockDefRange attaches the definition range (type and labels)
+// of the block enclosing the body being walked.
+func WithBlockDefRange(ctx context.Context, rng hcl.Range) context.Context {
+	return context.WithValue(ctx, blockDefRangeCtxKey{}, rng)
+}
+
+// BlockDefRange returns the definition range of the block enclosing
+// the body being walked, if any (i.e. not for the root body).
+func BlockDefRange(ctx context.Context) (hcl.Range, bool) {
+	rng, ok := ctx.Value(blockDefRangeCtxKey{}).(hcl.Range)
+	return rng, ok
+}
+
+// WithBlockType attaches the type of the block enclosing
+// the body being walked.
+func WithBlockType(ctx context.Context, blockType string) context.Context {
+	return context.WithValue(ctx, blockTypeCtxKey{}, blockType)
+}
+
+// BlockType returns the type of the block enclosing the body
+// being walked, if any (i.e. not for the root body).
+func BlockType(ctx context.Context) (string, bool) {
+	blockType, ok := ctx.Value(blockTypeCtxKey{}).(string)
+	return blockType, ok
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+type AllowedValues struct{}
+
+func (v AllowedValues) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if attrSchema.AllowedValuesFunc == nil {
+		return ctx, diags
+	}
+
+	val, valDiags := attr.Expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		// we can only validate static values
+		return ctx, diags
+	}
+
+	formattedValues := make([]string, 0)
+	for _, allowedVal := range attrSchema.AllowedValuesFunc(ctx) {
+		if !allowedVal.IsWhollyKnown() || allowedVal.IsNull() {
+			continue
+		}
+		if allowedVal.Type().Equals(val.Type()) && allowedVal.Equals(val).True() {
+			return ctx, diags
+		}
+		formattedValues = append(formattedValues, string(hclwrite.TokensForValue(allowedVal).Bytes()))
+	}
+
+	if len(formattedValues) == 0 {
+		// no known values to validate against
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid value",
+		Detail: fmt.Sprintf("The value of %q must be one of: %s",
+			attr.Name, strings.Join(formattedValues, ", ")),
+		Subject: attr.Expr.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
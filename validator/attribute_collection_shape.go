@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// CollectionShape reports collection constraints (such as list or map)
+// which are given a collection literal of the wrong shape,
+// e.g. an object where a list is expected.
+type CollectionShape struct{}
+
+func (v CollectionShape) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+
+	var expected string
+	var expectsTuple bool
+	switch attrSchema.Constraint.(type) {
+	case schema.List:
+		expected, expectsTuple = "a list", true
+	case schema.Set:
+		expected, expectsTuple = "a set", true
+	case schema.Tuple:
+		expected, expectsTuple = "a tuple", true
+	case schema.Map:
+		expected, expectsTuple = "a map", false
+	case schema.Object:
+		expected, expectsTuple = "an object", false
+	default:
+		return ctx, diags
+	}
+
+	var got string
+	switch attr.Expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		if expectsTuple {
+			return ctx, diags
+		}
+		got = "a tuple"
+	case *hclsyntax.ObjectConsExpr:
+		if !expectsTuple {
+			return ctx, diags
+		}
+		got = "an object"
+	default:
+		// other expressions (e.g. references) may still
+		// evaluate to the expected shape
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid collection shape",
+		Detail:   fmt.Sprintf("Expected %s for %q, got %s", expected, attr.Name, got),
+		Subject:  attr.Expr.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
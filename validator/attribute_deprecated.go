@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -30,10 +31,20 @@ func (v DeprecatedAttribute) Visit(ctx context.Context, node hclsyntax.Node, nod
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagWarning,
 			Summary:  fmt.Sprintf("%q is deprecated", attr.Name),
-			Detail:   fmt.Sprintf("Reason: %q", attrSchema.Description.Value),
-			Subject:  attr.SrcRange.Ptr(),
+			Detail:   deprecationDetail(attrSchema.DeprecationMessage, attrSchema.Description),
+			Subject:  attr.NameRange.Ptr(),
 		})
 	}
 
 	return ctx, diags
 }
+
+// deprecationDetail returns the diagnostic detail for a deprecated
+// attribute or block, preferring the explicit deprecation message
+// and falling back to the description
+func deprecationDetail(message string, description lang.MarkupContent) string {
+	if message != "" {
+		return message
+	}
+	return fmt.Sprintf("Reason: %q", description.Value)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
@@ -31,17 +32,24 @@ func (v MissingRequiredAttribute) Visit(ctx context.Context, node hclsyntax.Node
 		return ctx, diags
 	}
 
-	for name, attr := range bodySchema.Attributes {
-		if attr.IsRequired {
-			_, ok := body.Attributes[name]
-			if !ok {
-				diags = append(diags, &hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  fmt.Sprintf("Required attribute %q not specified", name),
-					Detail:   fmt.Sprintf("An attribute named %q is required here", name),
-					Subject:  body.SrcRange.Ptr(),
-				})
-			}
+	// point to the enclosing block, if any, as the body
+	// itself may span many lines
+	subject := body.SrcRange
+	if rng, ok := schemacontext.BlockDefRange(ctx); ok {
+		subject = rng
+	}
+
+	for _, name := range bodySchema.AttributeNames() {
+		if !bodySchema.Attributes[name].IsRequired {
+			continue
+		}
+		if _, ok := body.Attributes[name]; !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Required attribute %q not specified", name),
+				Detail:   fmt.Sprintf("An attribute named %q is required here", name),
+				Subject:  subject.Ptr(),
+			})
 		}
 	}
 
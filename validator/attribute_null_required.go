@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// NullRequiredAttribute reports null assigned to a required attribute
+// which is not nullable, as that is equivalent to leaving it unset.
+type NullRequiredAttribute struct{}
+
+func (v NullRequiredAttribute) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if !attrSchema.IsRequired || attrSchema.IsNullable {
+		return ctx, diags
+	}
+
+	val, valDiags := attr.Expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsKnown() || !val.IsNull() {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("Required attribute %q set to null", attr.Name),
+		Detail:   fmt.Sprintf("The attribute %q is required and cannot be null", attr.Name),
+		Subject:  attr.Expr.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
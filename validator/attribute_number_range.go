@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// NumberOutOfRange reports static numbers outside
+// of the NumberRange of the attribute.
+type NumberOutOfRange struct{}
+
+func (v NumberOutOfRange) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if attrSchema.NumberRange == nil {
+		return ctx, diags
+	}
+
+	val, valDiags := attr.Expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsKnown() || val.IsNull() || !val.Type().Equals(cty.Number) {
+		// we can only validate static numbers
+		return ctx, diags
+	}
+
+	if attrSchema.NumberRange.Contains(val) {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Value out of range",
+		Detail: fmt.Sprintf("Value of %q must be within %s, got %s",
+			attr.Name, attrSchema.NumberRange.String(), val.AsBigFloat().Text('f', -1)),
+		Subject: attr.Expr.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
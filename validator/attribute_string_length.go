@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// StringLength reports static string values which are shorter
+// than MinLength or longer than MaxLength of the attribute.
+type StringLength struct{}
+
+func (v StringLength) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if attrSchema.MinLength == 0 && attrSchema.MaxLength == 0 {
+		return ctx, diags
+	}
+
+	val, valDiags := attr.Expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsKnown() || val.IsNull() || !val.Type().Equals(cty.String) {
+		// we can only validate static strings
+		return ctx, diags
+	}
+
+	length := uint64(utf8.RuneCountInString(val.AsString()))
+
+	if attrSchema.MinLength != 0 && length < attrSchema.MinLength {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Value too short",
+			Detail: fmt.Sprintf("Value of %q must be at least %d characters long, got %d",
+				attr.Name, attrSchema.MinLength, length),
+			Subject: attr.Expr.Range().Ptr(),
+		})
+	}
+	if attrSchema.MaxLength != 0 && length > attrSchema.MaxLength {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Value too long",
+			Detail: fmt.Sprintf("Value of %q must be at most %d characters long, got %d",
+				attr.Name, attrSchema.MaxLength, length),
+			Subject: attr.Expr.Range().Ptr(),
+		})
+	}
+
+	return ctx, diags
+}
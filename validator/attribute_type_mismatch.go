@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// TypeMismatch reports static attribute values which cannot
+// be converted to the type expected by the attribute constraint,
+// e.g. a string where a number is expected.
+type TypeMismatch struct{}
+
+func (v TypeMismatch) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if attrSchema.Constraint == nil {
+		return ctx, diags
+	}
+
+	val, valDiags := attr.Expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		// we can only validate static values
+		return ctx, diags
+	}
+
+	if constraintAcceptsValue(attrSchema.Constraint, val) {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid value type",
+		Detail: fmt.Sprintf("Expected %s for %q, got %s",
+			attrSchema.Constraint.FriendlyName(), attr.Name, val.Type().FriendlyName()),
+		Subject: attr.Expr.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
+
+// constraintAcceptsValue returns false only if the value is known
+// not to be convertible to the type of the constraint.
+// Constraints which cannot be checked statically accept any value.
+func constraintAcceptsValue(cons schema.Constraint, val cty.Value) bool {
+	switch c := cons.(type) {
+	case schema.AnyExpression:
+		return true
+	case schema.LiteralType:
+		return isConvertible(val, c.Type)
+	case schema.LiteralValue:
+		return isConvertible(val, c.Value.Type())
+	case schema.OneOf:
+		for _, alt := range c {
+			if constraintAcceptsValue(alt, val) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func isConvertible(val cty.Value, typ cty.Type) bool {
+	_, err := convert.Convert(val, typ)
+	return err == nil
+}
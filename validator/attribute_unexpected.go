@@ -30,11 +30,16 @@ func (v UnexpectedAttribute) Visit(ctx context.Context, node hclsyntax.Node, nod
 	}
 
 	if nodeSchema == nil {
+		detail := fmt.Sprintf("An attribute named %q is not expected here", attr.Name)
+		if blockType, ok := schemacontext.BlockType(ctx); ok {
+			detail = fmt.Sprintf("An attribute named %q is not expected in a %q block", attr.Name, blockType)
+		}
+
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Unexpected attribute",
-			Detail:   fmt.Sprintf("An attribute named %q is not expected here", attr.Name),
-			Subject:  attr.SrcRange.Ptr(),
+			Detail:   detail,
+			Subject:  attr.NameRange.Ptr(),
 		})
 	}
 
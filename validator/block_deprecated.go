@@ -30,7 +30,7 @@ func (v DeprecatedBlock) Visit(ctx context.Context, node hclsyntax.Node, nodeSch
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagWarning,
 			Summary:  fmt.Sprintf("%q is deprecated", block.Type),
-			Detail:   fmt.Sprintf("Reason: %q", blockSchema.Description.Value),
+			Detail:   deprecationDetail(blockSchema.DeprecationMessage, blockSchema.Description),
 			Subject:  block.TypeRange.Ptr(),
 		})
 	}
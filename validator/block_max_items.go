@@ -8,17 +8,18 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/hcl-lang/schema"
-	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+// MaxBlocks reports every block of a type beyond its MaxItems,
+// anchoring each diagnostic at the header of the extra block.
 type MaxBlocks struct{}
 
 func (v MaxBlocks) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
-	_, ok := node.(*hclsyntax.Body)
+	body, ok := node.(*hclsyntax.Body)
 	if !ok {
 		return ctx, diags
 	}
@@ -27,20 +28,22 @@ func (v MaxBlocks) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema sc
 		return ctx, diags
 	}
 
-	foundBlocks := schemacontext.FoundBlocks(ctx)
-
 	bodySchema := nodeSchema.(*schema.BodySchema)
-	for name, blockSchema := range bodySchema.Blocks {
-		if blockSchema.MaxItems != 0 {
-			foundBlocks, ok := foundBlocks[name]
-			if ok && foundBlocks > blockSchema.MaxItems {
-				diags = append(diags, &hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  fmt.Sprintf("Too many blocks specified for %q", name),
-					Detail:   fmt.Sprintf("Only %d block(s) are expected for %q", blockSchema.MaxItems, name),
-					Subject:  node.Range().Ptr(),
-				})
-			}
+	seenBlocks := make(map[string]uint64)
+	for _, block := range body.Blocks {
+		blockSchema, ok := bodySchema.Blocks[block.Type]
+		if !ok || blockSchema.MaxItems == 0 {
+			continue
+		}
+
+		seenBlocks[block.Type]++
+		if seenBlocks[block.Type] > blockSchema.MaxItems {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Too many blocks specified for %q", block.Type),
+				Detail:   fmt.Sprintf("Only %d block(s) are expected for %q", blockSchema.MaxItems, block.Type),
+				Subject:  block.DefRange().Ptr(),
+			})
 		}
 	}
 